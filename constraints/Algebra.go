@@ -0,0 +1,250 @@
+package constraints
+
+import (
+	"slices"
+
+	version "github.com/CodeClarityCE/utility-node-semver/versions"
+)
+
+// Intersect returns the constraint that allows exactly the versions allowed by both a and b,
+// and whether any version is allowed at all
+//
+//	ex: ^1.2.0 and >=1.4.0 <3.0.0 := >=1.4.0 <2.0.0-0
+//
+// The resulting constraint is normalized into a disjunction of non-overlapping ranges, in ascending order.
+// Prerelease versions are ordered as per the semver spec, such that <2.0.0-0 excludes every prerelease of 2.0.0
+// while <2.0.0 does not.
+// If the intersection is empty, the returned constraint is <0.0.0-0 which cannot be satisfied.
+func Intersect(a Constraint, b Constraint) (Constraint, bool) {
+	intersection := a.IntervalSet().Intersect(b.IntervalSet())
+	return intersection.Constraint(), !intersection.IsEmpty()
+}
+
+// Equivalent returns true if a and b allow exactly the same versions
+//
+//	ex: ^1.0.0 and >=1.0.0 <2.0.0-0 are equivalent
+//	ex: ^1.0.0 and >=1.0.0 <2.0.0 are not, as the latter allows 2.0.0-beta when prereleases are included
+//
+// Equivalent constraints have the same Canonical form.
+func Equivalent(a Constraint, b Constraint) bool {
+	return a.IntervalSet().Equal(b.IntervalSet())
+}
+
+// According to the nodesemver spec, a prerelease version only satisfies a comparator set if one of
+// its comparators has a prerelease with the same [major, minor, patch] tuple.
+// Returns true if every prerelease that the bounds of sub allow is also allowed by the bounds of super.
+func allowsPreReleasesOf(super Interval, sub Interval) bool {
+	needed := []version.Semver{}
+	if sub.Lower.Type != UNBOUNDED && sub.Lower.Version.PreReleaseTag != "" {
+		needed = append(needed, sub.Lower.Version)
+	}
+	// <1.2.3-0 does not allow any prerelease of 1.2.3, it is the same as <1.2.3
+	if sub.Upper.Type != UNBOUNDED && sub.Upper.Version.PreReleaseTag != "" && (sub.Upper.Type == INCLUSIVE || sub.Upper.Version.PreReleaseTag != "0") {
+		needed = append(needed, sub.Upper.Version)
+	}
+
+	for _, v := range needed {
+		if !super.Lower.HasPreReleaseOf(v) && !super.Upper.HasPreReleaseOf(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns true if every version that satisfies sub also satisfies super
+//
+//	ex: ~1.2.3 is a subset of ^1.2.0
+//	ex: ^1.2.0 is not a subset of ~1.2.3
+//
+// As in nodesemver, each comparator set of sub must be a subset of a single comparator set of super,
+// so a range that is only covered by several comparator sets of super together is not a subset
+//
+//	ex: >=1.0.0 <3.0.0 is not a subset of >=1.0.0 <2.0.0 || >=2.0.0 <3.0.0
+//
+// Unlike nodesemver, comparator sets of sub that cannot be satisfied, e.g. >2.0.0 <1.0.0, are a subset of any constraint
+//
+// As in nodesemver, unless includePreReleases is set, a range that allows prereleases of a [major, minor, patch] tuple,
+// e.g. >=1.2.3-beta, is only a subset of a range that has a prerelease comparator with the same tuple.
+//
+//	ex: includePreReleases 'false' >=1.2.3-beta <1.3.0 is not a subset of >=1.0.0 <2.0.0
+//	ex: includePreReleases 'true' >=1.2.3-beta <1.3.0 is a subset of >=1.0.0 <2.0.0
+func IsSubset(sub Constraint, super Constraint, includePreReleases bool) bool {
+	superSets := super.comparatorSets()
+
+	for _, subSet := range sub.comparatorSets() {
+		if !slices.ContainsFunc(superSets, func(superSet IntervalSet) bool {
+			return isSetSubset(subSet, superSet, includePreReleases)
+		}) && !subSet.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if every interval of the comparator set sub is covered by the comparator set super
+func isSetSubset(sub IntervalSet, super IntervalSet, includePreReleases bool) bool {
+	for _, i := range sub {
+		container, ok := super.FindCovering(i)
+		if !ok {
+			return false
+		}
+		if !includePreReleases && !allowsPreReleasesOf(container, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the lowest version without a prerelease tag that lies above the lower bound
+func lowestReleaseAbove(b Bound) version.Semver {
+	if b.Type == UNBOUNDED {
+		return version.Semver{}
+	}
+	// A branch is not a numbered version, the only version of =dev-main is dev-main
+	if b.Version.IsUnaliasedBranch() {
+		return b.Version
+	}
+	release := version.Semver{Major: b.Version.Major, Minor: b.Version.Minor, Patch: b.Version.Patch}
+	// 1.2.3-beta < 1.2.3, but 1.2.3 is excluded by >1.2.3 and >=1.2.3 includes it anyway
+	if b.Version.PreReleaseTag == "" && b.Type == EXCLUSIVE {
+		release.Patch++
+	}
+	return release
+}
+
+// Returns the interval that contains all prereleases of the [major, minor, patch] tuple of v, i.e. [x.y.z-0, x.y.z)
+func preReleasesInterval(v version.Semver) Interval {
+	return Interval{
+		Lower: Bound{Type: INCLUSIVE, Version: version.Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch, PreReleaseTag: "0"}},
+		Upper: Bound{Type: EXCLUSIVE, Version: version.Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch}},
+	}
+}
+
+// Returns the lowest version of the interval that satisfies the comparator set it describes
+// Following nodesemver, a prerelease version only counts if one of the bounds has a prerelease of the same tuple
+func minVersionOf(i Interval) (version.Semver, bool) {
+	candidates := []version.Semver{lowestReleaseAbove(i.Lower)}
+
+	if i.Lower.Type != UNBOUNDED && i.Lower.Version.PreReleaseTag != "" {
+		lowest := i.Lower.Version
+		// 1.2.3-beta.0 is the lowest version above 1.2.3-beta
+		if i.Lower.Type == EXCLUSIVE {
+			lowest.PreReleaseTag += ".0"
+			lowest.Raw = ""
+		}
+		candidates = append(candidates, lowest)
+	}
+	if i.Upper.Type != UNBOUNDED && i.Upper.Version.PreReleaseTag != "" {
+		candidates = append(candidates, preReleasesInterval(i.Upper.Version).Lower.Version)
+	}
+
+	found := false
+	min := version.Semver{}
+	for _, candidate := range candidates {
+		if i.Contains(candidate) && (!found || candidate.LT(min, false)) {
+			min = candidate
+			found = true
+		}
+	}
+	return min, found
+}
+
+// MinVersion returns the lowest version that satisfies the constraint, and false if the constraint cannot be satisfied
+//
+//	ex: ^1.2.3 := 1.2.3
+//	ex: >1.2.3 := 1.2.4
+//	ex: >1.2.3-beta := 1.2.3-beta.0
+//	ex: <2.0.0 := 0.0.0
+//
+// As in the evaluator, a prerelease version is only returned if the constraint has a prerelease comparator with the same tuple.
+func MinVersion(c Constraint) (version.Semver, bool) {
+	for _, i := range c.IntervalSet() {
+		if min, ok := minVersionOf(i); ok {
+			return min, true
+		}
+	}
+	return version.Semver{}, false
+}
+
+// Returns true if the interval contains a version that satisfies both comparator sets described by i1 and i2
+// Unless includePreReleases is set, a prerelease version only counts if both sets allow prereleases of its tuple
+func admitsCommonVersion(i1 Interval, i2 Interval, includePreReleases bool) bool {
+	i := i1.Intersect(i2)
+	if i.IsEmpty() {
+		return false
+	}
+	if includePreReleases {
+		return true
+	}
+
+	if i.Contains(lowestReleaseAbove(i.Lower)) {
+		return true
+	}
+
+	for _, b := range []Bound{i1.Lower, i1.Upper} {
+		if b.Type == UNBOUNDED || b.Version.PreReleaseTag == "" {
+			continue
+		}
+		if !i2.Lower.HasPreReleaseOf(b.Version) && !i2.Upper.HasPreReleaseOf(b.Version) {
+			continue
+		}
+		if !i.Intersect(preReleasesInterval(b.Version)).IsEmpty() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Intersects returns true if there is any version that satisfies both c1 and c2, without the need for a list of versions
+//
+//	ex: ^1.2.0 and >=1.4.0 <3.0.0 intersect
+//	ex: ^1.2.0 and ^2.0.0 do not intersect
+//
+// As in the evaluator, includePreReleases can be used to allow prerelease versions
+// with different [major,minor,patch] tuple to satisfy the constraints
+//
+//	ex: includePreReleases 'false' >=1.0.0 <2.0.0-0 and >=2.0.0-0 do not intersect
+//	ex: includePreReleases 'false' >=1.0.0 <2.0.0 and >=2.0.0-0 do not intersect
+//	ex: includePreReleases 'true' >=1.0.0 <2.0.0 and >=2.0.0-0 intersect, as both allow 2.0.0-0
+func Intersects(c1 Constraint, c2 Constraint, includePreReleases bool) bool {
+	set2 := c2.IntervalSet()
+	for _, i1 := range c1.IntervalSet() {
+		for _, i2 := range set2 {
+			if admitsCommonVersion(i1, i2, includePreReleases) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Complement returns the constraint that allows exactly the versions not allowed by c
+//
+//	ex: >=1.2.0 <1.4.5 := <1.2.0 || >=1.4.5
+//
+// The resulting constraint is a disjunction of non-overlapping ranges, in ascending order.
+// If c allows every version, the returned constraint is <0.0.0-0 which cannot be satisfied.
+// Branches such as dev-main cannot be enumerated, so the complement only allows numbered versions.
+func Complement(c Constraint) Constraint {
+	return c.IntervalSet().Complement().Constraint()
+}
+
+// Difference returns the constraint that allows exactly the versions allowed by a, but not by b
+//
+//	ex: ^1.0.0 minus >=1.2.0 <1.4.5 := >=1.0.0 <1.2.0 || >=1.4.5 <2.0.0-0
+//
+// The resulting constraint is a disjunction of non-overlapping ranges, in ascending order.
+// If no version remains, the returned constraint is <0.0.0-0 which cannot be satisfied.
+func Difference(a Constraint, b Constraint) Constraint {
+	set, excluded := a.IntervalSet(), b.IntervalSet()
+	difference := set.Intersect(excluded.Complement())
+	// The complement of b only holds numbered versions, the branches of a remain unless b allows them
+	for _, i := range set {
+		if _, ok := excluded.FindCovering(i); i.branch() != "" && !ok {
+			difference = difference.Union(NewIntervalSet(i))
+		}
+	}
+	return difference.Constraint()
+}
@@ -0,0 +1,480 @@
+package constraints
+
+import (
+	"fmt"
+	"testing"
+)
+
+type IntersectionToTest struct {
+	ConstraintA      string
+	ConstraintB      string
+	ExpectedResult   string
+	ExpectedNonEmpty bool
+}
+
+func TestIntersect(t *testing.T) {
+
+	fmt.Printf("\n%s Testing constraint intersection %s\n", "----------------", "----------------")
+
+	intersectionsToTest := []IntersectionToTest{
+		{
+			ConstraintA:      "^1.2.0",
+			ConstraintB:      ">=1.4 <3",
+			ExpectedResult:   ">=1.4.0 <2.0.0-0",
+			ExpectedNonEmpty: true,
+		},
+		{
+			ConstraintA:      ">=1.0.0",
+			ConstraintB:      "<2.0.0-0",
+			ExpectedResult:   ">=1.0.0 <2.0.0-0",
+			ExpectedNonEmpty: true,
+		},
+		// <2.0.0-0 excludes all prereleases of 2.0.0, while <2.0.0 does not
+		{
+			ConstraintA:      "<2.0.0",
+			ConstraintB:      "<2.0.0-0",
+			ExpectedResult:   "<2.0.0-0",
+			ExpectedNonEmpty: true,
+		},
+		{
+			ConstraintA:      ">=2.0.0-0",
+			ConstraintB:      "<2.0.0",
+			ExpectedResult:   ">=2.0.0-0 <2.0.0",
+			ExpectedNonEmpty: true,
+		},
+		{
+			ConstraintA:      ">=2.0.0",
+			ConstraintB:      "<2.0.0",
+			ExpectedResult:   "<0.0.0-0",
+			ExpectedNonEmpty: false,
+		},
+		{
+			ConstraintA:      "<=2.0.0",
+			ConstraintB:      ">=2.0.0",
+			ExpectedResult:   "=2.0.0",
+			ExpectedNonEmpty: true,
+		},
+		{
+			ConstraintA:      "1.x || 3.x",
+			ConstraintB:      ">=1.5.0 <3.5.0",
+			ExpectedResult:   ">=1.5.0 <2.0.0-0 || >=3.0.0 <3.5.0",
+			ExpectedNonEmpty: true,
+		},
+		{
+			ConstraintA:      "4.0.0 || 5.x && < 5.5.0",
+			ConstraintB:      "*",
+			ExpectedResult:   "=4.0.0 || >=5.0.0 <5.5.0",
+			ExpectedNonEmpty: true,
+		},
+		{
+			ConstraintA:      ">=1.0.0 <2.0.0 || >=2.0.0 <3.0.0",
+			ConstraintB:      "*",
+			ExpectedResult:   ">=1.0.0 <3.0.0",
+			ExpectedNonEmpty: true,
+		},
+		// The prereleases of 2.0.0 are neither allowed by 1.x nor by 2.x
+		{
+			ConstraintA:      "1.x || 2.x",
+			ConstraintB:      "*",
+			ExpectedResult:   ">=1.0.0 <2.0.0-0 || >=2.0.0 <3.0.0-0",
+			ExpectedNonEmpty: true,
+		},
+		{
+			ConstraintA:      "5.2.x && 6.0.0",
+			ConstraintB:      "*",
+			ExpectedResult:   "<0.0.0-0",
+			ExpectedNonEmpty: false,
+		},
+	}
+
+	for _, intersectionToTest := range intersectionsToTest {
+		fmt.Printf("\nTesting intersection of '%s' and '%s'\n", intersectionToTest.ConstraintA, intersectionToTest.ConstraintB)
+
+		a, err := ParseConstraint(intersectionToTest.ConstraintA)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", intersectionToTest.ConstraintA, err)
+		}
+		b, err := ParseConstraint(intersectionToTest.ConstraintB)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", intersectionToTest.ConstraintB, err)
+		}
+
+		intersection, nonEmpty := Intersect(a, b)
+		if intersection.Original != intersectionToTest.ExpectedResult || nonEmpty != intersectionToTest.ExpectedNonEmpty {
+			fmt.Printf("✗ Failed. Expected: '%s' (%t), but got: '%s' (%t)\n", intersectionToTest.ExpectedResult, intersectionToTest.ExpectedNonEmpty, intersection.Original, nonEmpty)
+			t.Errorf("✗ Failed. Expected: '%s' (%t), but got: '%s' (%t)\n", intersectionToTest.ExpectedResult, intersectionToTest.ExpectedNonEmpty, intersection.Original, nonEmpty)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+type SubsetToTest struct {
+	Sub                string
+	Super              string
+	ExpectedResult     bool
+	IncludePreReleases bool
+	Ecosystem          string
+}
+
+func TestIsSubset(t *testing.T) {
+
+	fmt.Printf("\n%s Testing constraint subsets %s\n", "----------------", "----------------")
+
+	subsetsToTest := []SubsetToTest{
+		{Sub: "~1.2.3", Super: "^1.2.0", ExpectedResult: true},
+		{Sub: "^1.2.0", Super: "~1.2.3", ExpectedResult: false},
+		{Sub: "^1.2.0", Super: "^1.2.0", ExpectedResult: true},
+		{Sub: "=1.5.0", Super: "^1.2.0", ExpectedResult: true},
+		{Sub: "1.x || 2.x", Super: ">=1.0.0 <3.0.0", ExpectedResult: true},
+		// Each comparator set of sub must fit into a single comparator set of super, as in nodesemver
+		{Sub: ">=1.0.0 <3.0.0", Super: ">=1.0.0 <2.0.0 || >=2.0.0 <3.0.0", ExpectedResult: false},
+		{Sub: ">=1.0.0 <1.5.0 || >=2.0.0 <2.5.0", Super: ">=1.0.0 <2.0.0 || >=2.0.0 <3.0.0", ExpectedResult: true},
+		{Sub: "^1.2.0 || >2.0.0 <1.0.0", Super: "^1.0.0", ExpectedResult: true},
+		{Sub: ">=1.0.0 <3.0.0", Super: "1.x || 2.x", ExpectedResult: false},
+		{Sub: ">=1.0.0 <3.0.0", Super: "1.x || 2.x && < 2.5.0", ExpectedResult: false},
+		{Sub: ">1.0.0 <=2.0.0", Super: ">=1.0.0 <2.0.0", ExpectedResult: false},
+		{Sub: "<2.0.0", Super: ">=0.0.0 <2.0.0", ExpectedResult: false},
+		{Sub: "*", Super: ">=0.0.0", ExpectedResult: true},
+		{Sub: "5.2.x && 6.0.0", Super: "=1.0.0", ExpectedResult: true},
+
+		// Prereleases are only a subset of ranges that allow prereleases of the same tuple
+		{Sub: ">=1.2.3-beta <1.3.0", Super: ">=1.0.0 <2.0.0", ExpectedResult: false},
+		{Sub: ">=1.2.3-beta <1.3.0", Super: ">=1.0.0 <2.0.0", ExpectedResult: true, IncludePreReleases: true},
+		{Sub: ">=1.2.3-beta.2 <1.3.0", Super: ">=1.2.3-beta <2.0.0", ExpectedResult: true},
+		{Sub: "=1.2.3-beta", Super: "^1.0.0", ExpectedResult: false},
+		{Sub: ">=1.0.0 <2.0.0-0", Super: ">=1.0.0 <2.0.0", ExpectedResult: true},
+		{Sub: ">=1.0.0 <2.0.0", Super: ">=1.0.0 <2.0.0-0", ExpectedResult: false},
+
+		// Unaliased branches are not numbered versions, only the same branch covers them
+		{Sub: "dev-main", Super: ">=1.0", ExpectedResult: false, Ecosystem: "composer"},
+		{Sub: "dev-main", Super: "*", ExpectedResult: false, Ecosystem: "composer"},
+		{Sub: "dev-main", Super: "dev-main || >=1.0", ExpectedResult: true, Ecosystem: "composer"},
+		{Sub: ">=2.0", Super: "dev-main || >=1.0", ExpectedResult: true, IncludePreReleases: true, Ecosystem: "composer"},
+	}
+
+	for _, subsetToTest := range subsetsToTest {
+		fmt.Printf("\nTesting if '%s' is a subset of '%s'\n", subsetToTest.Sub, subsetToTest.Super)
+
+		sub, err := ParseConstraintWithEcosystem(subsetToTest.Sub, subsetToTest.Ecosystem)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", subsetToTest.Sub, err)
+		}
+		super, err := ParseConstraintWithEcosystem(subsetToTest.Super, subsetToTest.Ecosystem)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", subsetToTest.Super, err)
+		}
+
+		res := IsSubset(sub, super, subsetToTest.IncludePreReleases)
+		if res != subsetToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: %t, but got: %t\n", subsetToTest.ExpectedResult, res)
+			t.Errorf("✗ Failed. Expected: %t, but got: %t\n", subsetToTest.ExpectedResult, res)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+type IntersectsToTest struct {
+	ConstraintA        string
+	ConstraintB        string
+	ExpectedResult     bool
+	IncludePreReleases bool
+	Ecosystem          string
+}
+
+func TestIntersects(t *testing.T) {
+
+	fmt.Printf("\n%s Testing constraint overlap %s\n", "----------------", "----------------")
+
+	intersectsToTest := []IntersectsToTest{
+		{ConstraintA: "^1.2.0", ConstraintB: ">=1.4 <3", ExpectedResult: true},
+		{ConstraintA: "^1.2.0", ConstraintB: "^2.0.0", ExpectedResult: false},
+		{ConstraintA: "<=2.0.0", ConstraintB: ">=2.0.0", ExpectedResult: true},
+		{ConstraintA: "<2.0.0", ConstraintB: ">2.0.0", ExpectedResult: false},
+		{ConstraintA: "1.x || 3.x", ConstraintB: "2.x || 3.5.x", ExpectedResult: true},
+		{ConstraintA: "1.x || 3.x", ConstraintB: "2.x || 4.x", ExpectedResult: false},
+		{ConstraintA: ">1.2.3", ConstraintB: "<1.2.4", ExpectedResult: false},
+		{ConstraintA: ">1.2.3", ConstraintB: "<=1.2.4", ExpectedResult: true},
+
+		// The overlap only consists of prereleases
+		{ConstraintA: ">=1.0.0 <2.0.0", ConstraintB: ">=2.0.0-0", ExpectedResult: false},
+		{ConstraintA: ">=1.0.0 <2.0.0", ConstraintB: ">=2.0.0-0", ExpectedResult: true, IncludePreReleases: true},
+		{ConstraintA: ">=1.0.0 <2.0.0-0", ConstraintB: ">=2.0.0-0", ExpectedResult: false, IncludePreReleases: true},
+		{ConstraintA: ">=1.0.0 <2.0.0-rc.1", ConstraintB: ">=2.0.0-beta", ExpectedResult: true},
+		{ConstraintA: ">=1.0.0 <2.0.0-beta", ConstraintB: ">=2.0.0-rc.1", ExpectedResult: false},
+
+		// Unaliased branches only intersect the same branch
+		{ConstraintA: "dev-main", ConstraintB: ">=1.0", ExpectedResult: false, Ecosystem: "composer"},
+		{ConstraintA: "dev-main", ConstraintB: "dev-feature", ExpectedResult: false, Ecosystem: "composer"},
+		{ConstraintA: "dev-main", ConstraintB: "dev-main || ^1.0", ExpectedResult: true, Ecosystem: "composer"},
+	}
+
+	for _, intersectsToTest := range intersectsToTest {
+		fmt.Printf("\nTesting if '%s' and '%s' intersect\n", intersectsToTest.ConstraintA, intersectsToTest.ConstraintB)
+
+		a, err := ParseConstraintWithEcosystem(intersectsToTest.ConstraintA, intersectsToTest.Ecosystem)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", intersectsToTest.ConstraintA, err)
+		}
+		b, err := ParseConstraintWithEcosystem(intersectsToTest.ConstraintB, intersectsToTest.Ecosystem)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", intersectsToTest.ConstraintB, err)
+		}
+
+		res := Intersects(a, b, intersectsToTest.IncludePreReleases)
+		if res != intersectsToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: %t, but got: %t\n", intersectsToTest.ExpectedResult, res)
+			t.Errorf("✗ Failed. Expected: %t, but got: %t\n", intersectsToTest.ExpectedResult, res)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+type ComplementToTest struct {
+	Constraint     string
+	ExpectedResult string
+}
+
+type DifferenceToTest struct {
+	ConstraintA    string
+	ConstraintB    string
+	ExpectedResult string
+	Ecosystem      string
+}
+
+func TestComplement(t *testing.T) {
+
+	fmt.Printf("\n%s Testing constraint complement %s\n", "----------------", "----------------")
+
+	complementsToTest := []ComplementToTest{
+		{Constraint: ">=1.2.0 <1.4.5", ExpectedResult: "<1.2.0 || >=1.4.5"},
+		{Constraint: ">1.2.0 <=1.4.5", ExpectedResult: "<=1.2.0 || >1.4.5"},
+		{Constraint: "=1.2.0", ExpectedResult: "<1.2.0 || >1.2.0"},
+		{Constraint: ">=1.2.0", ExpectedResult: "<1.2.0"},
+		{Constraint: "^1.0.0", ExpectedResult: "<1.0.0 || >=2.0.0-0"},
+		{Constraint: "1.x || 3.x", ExpectedResult: "<1.0.0 || >=2.0.0-0 <3.0.0 || >=4.0.0-0"},
+		{Constraint: "<1.0.0 || >=1.0.0", ExpectedResult: "<0.0.0-0"},
+		// The complement of nothing is every version, including the prereleases of 0.0.0
+		{Constraint: "5.2.x && 6.0.0", ExpectedResult: "*"},
+		{Constraint: "<0.0.0-0", ExpectedResult: "*"},
+		{Constraint: "*", ExpectedResult: "<0.0.0-0"},
+	}
+
+	for _, complementToTest := range complementsToTest {
+		fmt.Printf("\nTesting complement of '%s'\n", complementToTest.Constraint)
+
+		c, err := ParseConstraint(complementToTest.Constraint)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", complementToTest.Constraint, err)
+		}
+
+		complement := Complement(c)
+		if complement.Original != complementToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: '%s', but got: '%s'\n", complementToTest.ExpectedResult, complement.Original)
+			t.Errorf("✗ Failed. Expected: '%s', but got: '%s'\n", complementToTest.ExpectedResult, complement.Original)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+func TestDifference(t *testing.T) {
+
+	fmt.Printf("\n%s Testing constraint difference %s\n", "----------------", "----------------")
+
+	differencesToTest := []DifferenceToTest{
+		{ConstraintA: "^1.0.0", ConstraintB: ">=1.2.0 <1.4.5", ExpectedResult: ">=1.0.0 <1.2.0 || >=1.4.5 <2.0.0-0"},
+		{ConstraintA: "^1.0.0", ConstraintB: "=1.3.2", ExpectedResult: ">=1.0.0 <1.3.2 || >1.3.2 <2.0.0-0"},
+		{ConstraintA: "^1.0.0", ConstraintB: ">=1.5.0", ExpectedResult: ">=1.0.0 <1.5.0"},
+		{ConstraintA: "^1.0.0", ConstraintB: "<3.0.0", ExpectedResult: "<0.0.0-0"},
+		{ConstraintA: "1.x || 2.x", ConstraintB: "1.5.x || >=2.1.0", ExpectedResult: ">=1.0.0 <1.5.0 || >=1.6.0-0 <2.0.0-0 || >=2.0.0 <2.1.0"},
+		// The complement of a constraint does not allow branches, but the branches of a remain
+		{ConstraintA: "dev-main || ^1.0", ConstraintB: ">=1.0", ExpectedResult: "=dev-main", Ecosystem: "composer"},
+		{ConstraintA: "dev-main || ^1.0", ConstraintB: "dev-main", ExpectedResult: ">=1.0.0-0 <2.0.0-0", Ecosystem: "composer"},
+	}
+
+	for _, differenceToTest := range differencesToTest {
+		fmt.Printf("\nTesting '%s' minus '%s'\n", differenceToTest.ConstraintA, differenceToTest.ConstraintB)
+
+		a, err := ParseConstraintWithEcosystem(differenceToTest.ConstraintA, differenceToTest.Ecosystem)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", differenceToTest.ConstraintA, err)
+		}
+		b, err := ParseConstraintWithEcosystem(differenceToTest.ConstraintB, differenceToTest.Ecosystem)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", differenceToTest.ConstraintB, err)
+		}
+
+		difference := Difference(a, b)
+		if difference.Original != differenceToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: '%s', but got: '%s'\n", differenceToTest.ExpectedResult, difference.Original)
+			t.Errorf("✗ Failed. Expected: '%s', but got: '%s'\n", differenceToTest.ExpectedResult, difference.Original)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+type EquivalenceToTest struct {
+	Constraint1    string
+	Constraint2    string
+	ExpectedResult bool
+}
+
+type CanonicalToTest struct {
+	ConstraintString string
+	ExpectedResult   string
+}
+
+func TestEquivalent(t *testing.T) {
+
+	fmt.Printf("\n%s Testing constraint equivalence %s\n", "----------------", "----------------")
+
+	equivalencesToTest := []EquivalenceToTest{
+		{Constraint1: "^1.0.0", Constraint2: ">=1.0.0 <2.0.0-0", ExpectedResult: true},
+		{Constraint1: "^1.0.0", Constraint2: ">=1.0.0 <2.0.0", ExpectedResult: false},
+		{Constraint1: "~1.2.3", Constraint2: "1.2.3 - 1.2", ExpectedResult: true},
+		{Constraint1: "1.x || 2.x", Constraint2: "2.x || 1.x", ExpectedResult: true},
+		{Constraint1: ">= 1.0.0 < 1.5.0 || ~1.4.2", Constraint2: ">=1.0.0 <1.5.0", ExpectedResult: true},
+		{Constraint1: ">= 1.0.0 <= 1.5.0 || > 1.5.0 < 2.0.0", Constraint2: ">=1.0.0 <2.0.0", ExpectedResult: true},
+		{Constraint1: "=1.2.3+build.1", Constraint2: "1.2.3", ExpectedResult: true},
+		{Constraint1: "^1.0.0", Constraint2: "~1.0.0", ExpectedResult: false},
+		{Constraint1: "5.2.x && 6.0.0", Constraint2: "<1.0.0 && >2.0.0", ExpectedResult: true},
+		{Constraint1: "<1.0.0 || >=1.0.0", Constraint2: ">=0.0.0-0", ExpectedResult: true},
+		{Constraint1: "<1.0.0 || >=1.0.0", Constraint2: "*", ExpectedResult: true},
+		{Constraint1: "*", Constraint2: ">=0.0.0-0", ExpectedResult: true},
+		{Constraint1: "*", Constraint2: ">=0.0.0 <1.0.0 || >=1.0.0", ExpectedResult: false},
+	}
+
+	for _, equivalenceToTest := range equivalencesToTest {
+		fmt.Printf("\nTesting equivalence of '%s' and '%s'\n", equivalenceToTest.Constraint1, equivalenceToTest.Constraint2)
+
+		c1, err := ParseConstraint(equivalenceToTest.Constraint1)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", equivalenceToTest.Constraint1, err)
+		}
+		c2, err := ParseConstraint(equivalenceToTest.Constraint2)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", equivalenceToTest.Constraint2, err)
+		}
+
+		equivalent := Equivalent(c1, c2)
+		sameCanonical := c1.Canonical() == c2.Canonical()
+		if equivalent != equivalenceToTest.ExpectedResult || sameCanonical != equivalenceToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: %t, but got: %t (canonical forms '%s' and '%s')\n", equivalenceToTest.ExpectedResult, equivalent, c1.Canonical(), c2.Canonical())
+			t.Errorf("✗ Failed. Expected: %t, but got: %t (canonical forms '%s' and '%s')\n", equivalenceToTest.ExpectedResult, equivalent, c1.Canonical(), c2.Canonical())
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+func TestCanonical(t *testing.T) {
+
+	fmt.Printf("\n%s Testing canonical form of constraints %s\n", "----------------", "----------------")
+
+	canonicalsToTest := []CanonicalToTest{
+		{ConstraintString: "^1.0.0", ExpectedResult: ">=1.0.0 <2.0.0-0"},
+		{ConstraintString: "2.x || 1.x", ExpectedResult: ">=1.0.0 <2.0.0-0 || >=2.0.0 <3.0.0-0"},
+		{ConstraintString: "1.2.3", ExpectedResult: "=1.2.3"},
+		{ConstraintString: "=1.2.3+build.1", ExpectedResult: "=1.2.3"},
+		{ConstraintString: "< 2.0.0 || > 3.0.0", ExpectedResult: "<2.0.0 || >3.0.0"},
+		{ConstraintString: "5.2.x && 6.0.0", ExpectedResult: "<0.0.0-0"},
+		{ConstraintString: ">=1.0.0 !=1.3.2 <2.0.0", ExpectedResult: ">=1.0.0 <1.3.2 || >1.3.2 <2.0.0"},
+		{ConstraintString: "!=1.0.0", ExpectedResult: "<1.0.0 || >1.0.0"},
+		// Every version is rendered as *, however it is spelled
+		{ConstraintString: "<1.0.0 || >=1.0.0", ExpectedResult: "*"},
+		{ConstraintString: ">=0.0.0-0", ExpectedResult: "*"},
+		{ConstraintString: "*", ExpectedResult: "*"},
+		{ConstraintString: ">=0.0.0-0 <1.0.0", ExpectedResult: "<1.0.0"},
+		// Only a lone >= 0.0.0 is read as *, a bounded range starting at 0.0.0 excludes the prereleases of 0.0.0
+		{ConstraintString: ">=0.0.0 <1.0.0", ExpectedResult: ">=0.0.0 <1.0.0"},
+	}
+
+	for _, canonicalToTest := range canonicalsToTest {
+		fmt.Printf("\nTesting canonical form of '%s'\n", canonicalToTest.ConstraintString)
+
+		c, err := ParseConstraint(canonicalToTest.ConstraintString)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", canonicalToTest.ConstraintString, err)
+		}
+
+		canonical := c.Canonical()
+		if canonical != canonicalToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: '%s', but got: '%s'\n", canonicalToTest.ExpectedResult, canonical)
+			t.Errorf("✗ Failed. Expected: '%s', but got: '%s'\n", canonicalToTest.ExpectedResult, canonical)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+type MinVersionToTest struct {
+	ConstraintString string
+	ExpectedVersion  string
+	ExpectedOk       bool
+}
+
+func TestMinVersion(t *testing.T) {
+
+	fmt.Printf("\n%s Testing minimal version of constraints %s\n", "----------------", "----------------")
+
+	minVersionsToTest := []MinVersionToTest{
+		{ConstraintString: "*", ExpectedVersion: "0.0.0", ExpectedOk: true},
+		{ConstraintString: "<2.0.0", ExpectedVersion: "0.0.0", ExpectedOk: true},
+		{ConstraintString: "<0.0.0-beta", ExpectedVersion: "0.0.0-0", ExpectedOk: true},
+		{ConstraintString: "^1.2.3", ExpectedVersion: "1.2.3", ExpectedOk: true},
+		{ConstraintString: "~1.2", ExpectedVersion: "1.2.0", ExpectedOk: true},
+		{ConstraintString: ">1.2.3", ExpectedVersion: "1.2.4", ExpectedOk: true},
+		{ConstraintString: ">=1.2.3-beta", ExpectedVersion: "1.2.3-beta", ExpectedOk: true},
+		{ConstraintString: ">1.2.3-beta", ExpectedVersion: "1.2.3-beta.0", ExpectedOk: true},
+		{ConstraintString: ">1.2.3 <=1.2.4-beta", ExpectedVersion: "1.2.4-0", ExpectedOk: true},
+		{ConstraintString: "^2.0.0 || >1.5.0 <1.6.0", ExpectedVersion: "1.5.1", ExpectedOk: true},
+		{ConstraintString: ">1.2.3 <1.2.4", ExpectedVersion: "", ExpectedOk: false},
+		{ConstraintString: "<0.0.0", ExpectedVersion: "", ExpectedOk: false},
+		{ConstraintString: "5.2.x && 6.0.0", ExpectedVersion: "", ExpectedOk: false},
+	}
+
+	for _, minVersionToTest := range minVersionsToTest {
+		fmt.Printf("\nTesting minimal version of '%s'\n", minVersionToTest.ConstraintString)
+
+		c, err := ParseConstraint(minVersionToTest.ConstraintString)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", minVersionToTest.ConstraintString, err)
+		}
+
+		min, ok := MinVersion(c)
+		if ok != minVersionToTest.ExpectedOk || (ok && min.String() != minVersionToTest.ExpectedVersion) {
+			fmt.Printf("✗ Failed. Expected: '%s' (%t), but got: '%s' (%t)\n", minVersionToTest.ExpectedVersion, minVersionToTest.ExpectedOk, min.String(), ok)
+			t.Errorf("✗ Failed. Expected: '%s' (%t), but got: '%s' (%t)\n", minVersionToTest.ExpectedVersion, minVersionToTest.ExpectedOk, min.String(), ok)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
@@ -0,0 +1,549 @@
+package constraints
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	versions "github.com/CodeClarityCE/utility-node-semver/versions"
+)
+
+// A stability flag at the end of a Composer version, e.g. >=5.3@dev
+var composerStabilityFlag = regexp.MustCompile(`(?i)@(stable|rc|beta|alpha|dev)$`)
+
+// A constraint on a single version that gives it an inline alias, e.g. dev-main as 1.0.x-dev
+var composerInlineAlias = regexp.MustCompile(`^\s*([^,\s]+) +as +([^,\s]+)\s*$`)
+
+// Operators of the Composer grammar, that are not part of a version
+const composerOperatorRunes = "<>=!~^"
+
+// Equivalent to lexConstraint, but for the Composer grammar
+// A comma or whitespace between two comparators is a conjunction, and a single or double pipe is a disjunction
+//
+//	ex: ^1.2, <1.5 := ^ 1.2 && < 1.5
+//	ex: 1.0.* | 2.* := 1.0.* || 2.*
+//	ex: >=1.0 <2.0 := >= 1.0 && < 2.0
+//
+// An inline alias only renames the version that is required, the alias is not part of the constraint
+//
+//	ex: dev-main as 1.0.x-dev := dev-main
+//
+// The tokens still carry the meaning of Composer, they are desugared by desugarComposerTokens once validated
+func lexComposerConstraint(constraint string) (tokens []Token, literals []string, offsets []int) {
+	tokens, literals, offsets = []Token{SOF}, []string{""}, []int{0}
+	if match := composerInlineAlias.FindStringSubmatchIndex(constraint); match != nil {
+		constraint = constraint[:match[3]]
+	}
+	emit := func(token Token, literal string, offset int) {
+		tokens = append(tokens, token)
+		literals = append(literals, literal)
+		offsets = append(offsets, offset)
+	}
+
+	for idx := 0; idx < len(constraint); {
+		start := idx
+		ch := rune(constraint[idx])
+
+		switch {
+		case isWhitespace(ch):
+			for idx < len(constraint) && isWhitespace(rune(constraint[idx])) {
+				idx++
+			}
+			emit(WS, constraint[start:idx], start)
+
+		case ch == '|':
+			idx++
+			if idx < len(constraint) && constraint[idx] == '|' {
+				idx++
+			}
+			emit(OR, constraint[start:idx], start)
+
+		case ch == ',':
+			idx++
+			emit(AND, ",", start)
+
+		// A hyphen is only a range if it is surrounded by whitespace, e.g. 1.0 - 2.0
+		case ch == '-' && tokens[len(tokens)-1] == WS && (idx+1 == len(constraint) || isWhitespace(rune(constraint[idx+1]))):
+			idx++
+			emit(HYPHEN, "-", start)
+
+		case strings.ContainsRune(composerOperatorRunes, ch):
+			for idx < len(constraint) && strings.ContainsRune(composerOperatorRunes, rune(constraint[idx])) {
+				idx++
+			}
+			emit(composerOperator(constraint[start:idx]), constraint[start:idx], start)
+
+		default:
+			for idx < len(constraint) && !isWhitespace(rune(constraint[idx])) && !strings.ContainsRune(composerOperatorRunes+",|", rune(constraint[idx])) {
+				idx++
+			}
+			emit(VERSION_EXPRESSION, constraint[start:idx], start)
+		}
+	}
+	emit(EOF, "", len(constraint))
+
+	return joinComposerComparators(tokens, literals, offsets)
+}
+
+func composerOperator(literal string) Token {
+	switch literal {
+	case "=", "==":
+		return EQ
+	case "!=", "<>":
+		return NOT
+	case "<":
+		return LT
+	case "<=":
+		return LE
+	case ">":
+		return GT
+	case ">=":
+		return GE
+	case "~", "~>":
+		return TILDE
+	case "^":
+		return CARET
+	}
+	return ILLEGAL
+}
+
+// Removes the whitespace tokens, where whitespace between two comparators is replaced by a conjunction
+//
+//	ex: >=1.0 <2.0 := >= 1.0 && < 2.0
+func joinComposerComparators(tokens []Token, literals []string, offsets []int) ([]Token, []string, []int) {
+	tokensToReturn := []Token{}
+	literalsToReturn := []string{}
+	offsetsToReturn := []int{}
+
+	for idx, token := range tokens {
+		if token == WS {
+			previousToken := tokensToReturn[len(tokensToReturn)-1]
+			nextToken := tokens[idx+1]
+			startsComparator := nextToken == VERSION_EXPRESSION || (IsRangeToken(nextToken) && nextToken != HYPHEN) || IsEqualityToken(nextToken)
+			if previousToken == VERSION_EXPRESSION && startsComparator {
+				tokensToReturn = append(tokensToReturn, AND)
+				literalsToReturn = append(literalsToReturn, literals[idx])
+				offsetsToReturn = append(offsetsToReturn, offsets[idx])
+			}
+			continue
+		}
+		tokensToReturn = append(tokensToReturn, token)
+		literalsToReturn = append(literalsToReturn, literals[idx])
+		offsetsToReturn = append(offsetsToReturn, offsets[idx])
+	}
+
+	return tokensToReturn, literalsToReturn, offsetsToReturn
+}
+
+// Desugars the validated tokens of a Composer constraint into comparators, whose meaning is the same in both grammars
+// Returns the least stable of the stability flags of the constraint, e.g. beta for ^2.0@beta || ^1.0@RC
+// A version without a flag implies the stability of its suffix, e.g. alpha for ^2.0-alpha2
+//
+// As Composer does, lower bounds and upper bounds that are not inclusive include the prereleases of their version, here written as the -0 prerelease
+//
+//	ex: ~1.2 := >=1.2.0-0 <2.0.0-0
+//	ex: ~1.2.3 := >=1.2.3-0 <1.3.0-0
+//	ex: ^0.3 := >=0.3.0-0 <0.4.0-0
+//	ex: 1.0.* := >=1.0.0-0 <1.1.0-0
+//	ex: 1.0 - 2.0 := >=1.0.0-0 <2.1.0-0
+//	ex: >=5.3@dev := >=5.3.0-0
+//	ex: >5.3@beta := >5.3.0-beta
+//	ex: 1.2 := =1.2.0
+//
+// Versions that are not numeric, e.g. dev-master, are not supported in constraints and return a *ParseError
+func desugarComposerTokens(tokens []Token, literals []string, offsets []int) ([]Token, []string, []int, string, error) {
+	tokensToReturn := []Token{}
+	literalsToReturn := []string{}
+	offsetsToReturn := []int{}
+	stability := ""
+
+	emit := func(comparators []composerComparator, offset int) {
+		for _, comparator := range comparators {
+			tokensToReturn = append(tokensToReturn, comparator.operator, VERSION_EXPRESSION)
+			literalsToReturn = append(literalsToReturn, comparator.operator.toString(), comparator.version)
+			offsetsToReturn = append(offsetsToReturn, offset, offset)
+		}
+	}
+	stripFlag := func(literal string) (string, string) {
+		literal, flag := stripComposerStabilityFlag(literal)
+		// As Composer does, a version without a flag implies the stability of its suffix, e.g. ^2.0-beta := ^2.0@beta
+		implied := flag
+		if implied == "" {
+			if version, err := versions.ParseSemverWithEcosystem(literal, versions.COMPOSER_ECOSYSTEM); err == nil && version.ComposerStability() != "stable" {
+				implied = version.ComposerStability()
+			}
+		}
+		if implied != "" && (stability == "" || slices.Index(versions.ComposerStabilities, implied) < slices.Index(versions.ComposerStabilities, stability)) {
+			stability = implied
+		}
+		return literal, flag
+	}
+
+	for idx := 0; idx < len(tokens); idx++ {
+		token := tokens[idx]
+
+		switch {
+		case token == VERSION_EXPRESSION && tokens[idx+1] == HYPHEN && tokens[idx+2] == VERSION_EXPRESSION:
+			start, _ := stripFlag(literals[idx])
+			end, _ := stripFlag(literals[idx+2])
+			comparators, invalidIdx, err := desugarComposerHyphen(start, end)
+			if err != nil {
+				return nil, nil, nil, "", newComposerVersionError(literals, offsets, idx+2*invalidIdx, err)
+			}
+			emit(comparators, offsets[idx])
+			idx += 2
+
+		case (IsRangeToken(token) || IsEqualityToken(token)) && tokens[idx+1] == VERSION_EXPRESSION:
+			literal, flag := stripFlag(literals[idx+1])
+			comparators, err := desugarComposerComparator(token, literal, flag)
+			if err != nil {
+				return nil, nil, nil, "", newComposerVersionError(literals, offsets, idx+1, err)
+			}
+			emit(comparators, offsets[idx])
+			idx++
+
+		case token == VERSION_EXPRESSION:
+			literal, flag := stripFlag(literals[idx])
+			comparators, err := desugarComposerComparator(EQ, literal, flag)
+			if err != nil {
+				return nil, nil, nil, "", newComposerVersionError(literals, offsets, idx, err)
+			}
+			emit(comparators, offsets[idx])
+
+		default:
+			tokensToReturn = append(tokensToReturn, token)
+			literalsToReturn = append(literalsToReturn, literals[idx])
+			offsetsToReturn = append(offsetsToReturn, offsets[idx])
+		}
+	}
+
+	return tokensToReturn, literalsToReturn, offsetsToReturn, stability, nil
+}
+
+func newComposerVersionError(literals []string, offsets []int, position int, cause error) *ParseError {
+	parseError := newParseError(ErrInvalidVersion, ERR_INVALID_VERSION, "Found invalid version", literals, offsets, position)
+	parseError.Cause = cause
+	return parseError
+}
+
+type composerComparator struct {
+	operator Token
+	version  string
+}
+
+// Returns the version without its stability flag, and the flag in the casing of versions.ComposerStabilities
+// A version that only consists of a flag matches any version
+//
+//	ex: >=5.3@dev := 5.3 and dev
+//	ex: @rc := * and RC
+func stripComposerStabilityFlag(literal string) (string, string) {
+	match := composerStabilityFlag.FindStringSubmatch(literal)
+	if match == nil {
+		return literal, ""
+	}
+
+	literal = strings.TrimSuffix(literal, match[0])
+	if literal == "" {
+		literal = "*"
+	}
+	for _, stability := range versions.ComposerStabilities {
+		if strings.EqualFold(stability, match[1]) {
+			return literal, stability
+		}
+	}
+	return literal, ""
+}
+
+// A Composer version split into its numeric parts and its suffix
+type composerVersion struct {
+	parts []uint64
+	// Whether the version ends in a wildcard, e.g. 1.0.* or 1.x
+	wildcard bool
+	// Whether the version has the dev modifier, e.g. 1.0.0-dev or 1.0.x-dev
+	dev bool
+	// The prerelease and metadata of the version including its separator, e.g. -beta1
+	suffix string
+}
+
+// Returns false if the version is not numeric, e.g. dev-master
+func parseComposerVersion(literal string) (composerVersion, bool) {
+	parsed := composerVersion{}
+
+	release := literal
+	if len(release) > 1 && (release[0] == 'v' || release[0] == 'V') && isDigit(rune(release[1])) {
+		release = release[1:]
+	}
+	release, metaData, hasMetaData := strings.Cut(release, "+")
+	modifier := ""
+	if idx := strings.Index(release, "-"); idx >= 0 {
+		release, modifier = release[:idx], release[idx:]
+	}
+
+	// The stability of the modifier is expanded as in versions, such that the bound compares to the versions of that stability
+	//   e.g. 1.0.0-b1 := 1.0.0-beta1 and 1.0.0-rc1 := 1.0.0-RC1
+	modifier, ok := versions.NormalizeComposerModifier(modifier)
+	if !ok {
+		return composerVersion{}, false
+	}
+	// Dev is the least of the stabilities, as the -0 prerelease is the least of the prereleases
+	//   e.g. 1.0.0-dev := 1.0.0-0
+	if modifier == "-dev" {
+		parsed.dev = true
+		modifier = "-0"
+	}
+	parsed.suffix = modifier
+	if hasMetaData {
+		parsed.suffix += "+" + metaData
+	}
+
+	// Composer versions have up to four parts, the fourth being the revision, e.g. 1.2.3.4
+	parts := strings.Split(release, ".")
+	if len(parts) > 4 {
+		return composerVersion{}, false
+	}
+	for _, part := range parts {
+		if part == "*" || part == "x" || part == "X" {
+			parsed.wildcard = true
+			continue
+		}
+		number, err := strconv.ParseUint(part, 10, 64)
+		// A wildcard can only be followed by wildcards, e.g. 1.*.3 is not valid
+		if err != nil || parsed.wildcard {
+			return composerVersion{}, false
+		}
+		parsed.parts = append(parsed.parts, number)
+	}
+
+	if parsed.wildcard && parsed.suffix != "" {
+		if !parsed.dev {
+			return composerVersion{}, false
+		}
+		// A wildcard with the dev modifier is the head of a branch rather than a wildcard, its missing parts are the highest
+		//   e.g. 1.0.x-dev := 1.0.9999999.9999999-dev, as VersionParser::normalize does
+		parsed.wildcard = false
+		for len(parsed.parts) < 4 {
+			parsed.parts = append(parsed.parts, composerWildcard)
+		}
+	}
+
+	return parsed, true
+}
+
+// Wildcard parts of a branch are parsed as this number, as in versions
+const composerWildcard = 9999999
+
+// Returns the version as it is required exactly, i.e. padded, where a dev version keeps its modifier, see parseComposerDevRange
+//
+//	ex: 1.0.x-dev := 1.0.9999999.9999999-dev
+//	ex: 1.2 := 1.2.0
+func (v composerVersion) exact() string {
+	if !v.dev {
+		return v.padded("")
+	}
+	parts := make([]uint64, 4)
+	copy(parts, v.parts)
+	return formatComposerParts(parts) + "-dev"
+}
+
+// Returns the version with its missing parts as zeros, and with the given suffix if it has none
+// The revision is only written if it is not zero, see splitComposerRevisions
+func (v composerVersion) padded(defaultSuffix string) string {
+	parts := make([]uint64, 4)
+	copy(parts, v.parts)
+
+	suffix := v.suffix
+	if suffix == "" {
+		suffix = defaultSuffix
+	}
+	return formatComposerParts(parts) + suffix
+}
+
+// Returns the major, minor, patch and revision parts as a release, where a zero revision is omitted
+//
+//	ex: [1, 2, 3, 0] := 1.2.3
+//	ex: [1, 2, 3, 4] := 1.2.3.4
+func formatComposerParts(parts []uint64) string {
+	release := fmt.Sprintf("%d.%d.%d", parts[0], parts[1], parts[2])
+	if parts[3] != 0 {
+		release += "." + strconv.FormatUint(parts[3], 10)
+	}
+	return release
+}
+
+// Returns the least version, that is greater than all versions sharing the given number of leading parts
+// The highest possible part has no successor, so it is rejected with ErrVersionPartOverflow rather than wrapped around
+//
+//	ex: 1.2.3 bumped at 1 := 2.0.0-0
+//	ex: 1.2.3 bumped at 2 := 1.3.0-0
+//	ex: 18446744073709551615.0 bumped at 1 := ErrVersionPartOverflow
+func (v composerVersion) bumped(leadingParts int) (string, error) {
+	parts := make([]uint64, 4)
+	copy(parts, v.parts[:leadingParts])
+	if parts[leadingParts-1] == math.MaxUint64 {
+		partName := []versions.VersionPart{versions.MAJOR_PART, versions.MINOR_PART, versions.PATCH_PART, versions.REVISION_PART}[leadingParts-1]
+		return "", &versions.InvalidVersionError{Version: v.padded(""), Part: partName, Identifier: strconv.FormatUint(parts[leadingParts-1], 10), Reason: versions.ErrVersionPartOverflow}
+	}
+	parts[leadingParts-1]++
+	return formatComposerParts(parts) + "-0", nil
+}
+
+// Returns the comparators of the range from the version, that includes its prereleases, up to the version bumped at the given number of leading parts
+//
+//	ex: ~1.2.3 := >=1.2.3-0 <1.3.0-0
+func (v composerVersion) bumpedRange(leadingParts int) ([]composerComparator, error) {
+	end, err := v.bumped(leadingParts)
+	if err != nil {
+		return nil, err
+	}
+	return []composerComparator{{operator: GE, version: v.padded("-0")}, {operator: LT, version: end}}, nil
+}
+
+// Desugars a Composer comparator, the flag is the stability flag that was stripped from its version
+// A dev branch is kept as is, it can only be required exactly, e.g. dev-main or ==dev-main
+// Returns versions.ErrInvalidVersionParts if the version is neither numeric nor a dev branch that is required exactly
+func desugarComposerComparator(operator Token, literal string, flag string) ([]composerComparator, error) {
+	if isComposerBranch(literal) {
+		if operator != EQ {
+			return nil, versions.ErrInvalidVersionParts
+		}
+		return []composerComparator{{operator: EQ, version: literal}}, nil
+	}
+
+	version, ok := parseComposerVersion(literal)
+	if !ok {
+		return nil, versions.ErrInvalidVersionParts
+	}
+
+	// A stability flag lowers the bound of a stable version to the prereleases of that stability, e.g. >5.3@beta := >5.3.0-beta
+	flagSuffix := ""
+	if flag != "" && flag != "stable" && version.suffix == "" {
+		flagSuffix = "-" + flag
+		if flag == "dev" {
+			flagSuffix = "-0"
+		}
+	}
+
+	// e.g. 1.0.* := >=1.0.0-0 <1.1.0-0 and * := >=0.0.0-0
+	if version.wildcard && (operator == EQ || operator == TILDE || operator == CARET) {
+		if len(version.parts) == 0 {
+			return []composerComparator{{operator: GE, version: "0.0.0-0"}}, nil
+		}
+		return version.bumpedRange(len(version.parts))
+	}
+
+	switch operator {
+	case TILDE:
+		// The last given part may increase, where a single major part is taken as ~1.0, e.g. ~1 := >=1.0.0-0 <2.0.0-0
+		leadingParts := max(len(version.parts)-1, 1)
+		return version.bumpedRange(leadingParts)
+	case CARET:
+		// The left most non-zero part may not change, e.g. ^0.3 := >=0.3.0-0 <0.4.0-0
+		leadingParts := len(version.parts)
+		for idx, part := range version.parts {
+			if part != 0 {
+				leadingParts = idx + 1
+				break
+			}
+		}
+		return version.bumpedRange(leadingParts)
+	case GE, LT:
+		if flagSuffix == "" {
+			flagSuffix = "-0"
+		}
+		return []composerComparator{{operator: operator, version: version.padded(flagSuffix)}}, nil
+	case GT, LE:
+		return []composerComparator{{operator: operator, version: version.padded(flagSuffix)}}, nil
+	default:
+		return []composerComparator{{operator: operator, version: version.exact()}}, nil
+	}
+}
+
+// Desugars a Composer hyphen range, whose upper bound includes all versions that share the parts of a partial version
+//
+//	ex: 1.0 - 2.0 := >=1.0.0-0 <2.1.0-0
+//	ex: 1.0.0 - 2.1.0 := >=1.0.0-0 <=2.1.0
+//
+// Returns the index of the side whose version is invalid along with the error, e.g. 1 and versions.ErrInvalidVersionParts if the end is not numeric
+func desugarComposerHyphen(startLiteral string, endLiteral string) ([]composerComparator, int, error) {
+	start, ok := parseComposerVersion(startLiteral)
+	if !ok || start.wildcard {
+		return nil, 0, versions.ErrInvalidVersionParts
+	}
+	end, ok := parseComposerVersion(endLiteral)
+	if !ok || end.wildcard {
+		return nil, 1, versions.ErrInvalidVersionParts
+	}
+
+	comparators := []composerComparator{{operator: GE, version: start.padded("-0")}}
+	if len(end.parts) < 3 && end.suffix == "" {
+		bumped, err := end.bumped(len(end.parts))
+		if err != nil {
+			return nil, 1, err
+		}
+		return append(comparators, composerComparator{operator: LT, version: bumped}), -1, nil
+	}
+	return append(comparators, composerComparator{operator: LE, version: end.padded("")}), -1, nil
+}
+
+func isComposerBranch(literal string) bool {
+	return len(literal) > len("dev-") && strings.EqualFold(literal[:len("dev-")], "dev-")
+}
+
+// Returns the range of a comparator that requires or excludes a dev version exactly, and false if the sub constraint is not one
+// The version of the range is a Composer version, such that it only equals the dev version itself rather than all of its stabilities.
+// A dev branch is kept in the version of the range, whose numeric parts are not compared, see versions.Semver.IsUnaliasedBranch
+//
+//	ex: =dev-main := Range{StartOp: EQ, StartVersion: dev-main}
+//	ex: =1.0.9999999.9999999-dev := Range{StartOp: EQ, StartVersion: 1.0.x-dev}
+func parseComposerDevRange(tokens []Token, literals []string) (Range, bool) {
+	if len(tokens) != 2 || (tokens[0] != EQ && tokens[0] != NOT) || tokens[1] != VERSION_EXPRESSION {
+		return Range{}, false
+	}
+	dev, err := versions.ParseSemverWithEcosystem(literals[1], versions.COMPOSER_ECOSYSTEM)
+	if err != nil || !dev.IsDev {
+		return Range{}, false
+	}
+	return Range{StartOp: tokens[0], StartVersion: dev}, true
+}
+
+// Splits the revisions off the versions of desugared Composer comparators, as the range parsers only take three parts
+// Returns the literals without revisions and the revision of each token, 0 for tokens without one
+//
+//	ex: [>=, 1.2.3.4-0, <, 1.2.4-0] := [>=, 1.2.3-0, <, 1.2.4-0] and [0, 4, 0, 0]
+func splitComposerRevisions(tokens []Token, literals []string) ([]string, []uint64) {
+	split := slices.Clone(literals)
+	revisions := make([]uint64, len(tokens))
+	for idx, token := range tokens {
+		if token != VERSION_EXPRESSION {
+			continue
+		}
+		release, suffix := split[idx], ""
+		if sep := strings.IndexAny(release, "-+"); sep >= 0 {
+			release, suffix = release[:sep], release[sep:]
+		}
+		// The desugared versions are numeric, so only a dev branch can fail to parse, and it has no revision
+		if parts := strings.Split(release, "."); len(parts) == 4 {
+			if revision, err := strconv.ParseUint(parts[3], 10, 64); err == nil {
+				revisions[idx] = revision
+				split[idx] = strings.Join(parts[:3], ".") + suffix
+			}
+		}
+	}
+	return split, revisions
+}
+
+// Returns the range of desugared Composer comparators with the revisions that were split off their versions, given in the order of the versions
+//
+//	ex: >=1.2.3-0 <1.2.4-0 and [4, 0] := >=1.2.3.4-0 <1.2.4-0
+func withComposerRevisions(r Range, revisions []uint64) Range {
+	if len(revisions) > 0 && revisions[0] != 0 {
+		r.StartVersion.Revision = revisions[0]
+	}
+	if len(revisions) > 1 && revisions[1] != 0 {
+		r.EndVersion.Revision = revisions[1]
+	}
+	return r
+}
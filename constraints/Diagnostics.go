@@ -0,0 +1,113 @@
+package constraints
+
+import (
+	"context"
+	"log/slog"
+)
+
+type Severity string
+
+const (
+	SEVERITY_INFO    Severity = "info"
+	SEVERITY_WARNING Severity = "warning"
+	SEVERITY_ERROR   Severity = "error"
+)
+
+// A DiagnosticCode identifies the kind of a Diagnostic
+// Diagnostics of errors use the code of the ParseError, e.g. "misplaced_join"
+type DiagnosticCode string
+
+const (
+	WARN_PARTIAL_HYPHEN_VERSION DiagnosticCode = "partial_hyphen_version"
+	// A loose form was rewritten into its regular form, see ParseOptions.Loose
+	INFO_LOOSE_REWRITE DiagnosticCode = "loose_rewrite"
+)
+
+// A Diagnostic is an info, a warning or an error found while parsing a constraint
+type Diagnostic struct {
+	Severity Severity
+	Code     DiagnosticCode
+	// The constraint the diagnostic was found in
+	Constraint string
+	// Byte offset and literal of the token the diagnostic refers to
+	Offset  int
+	Literal string
+	Message string
+}
+
+// A DiagnosticsSink receives the diagnostics of the parser
+// The parser never logs on its own, diagnostics are dropped unless a sink is given in the ParseOptions
+type DiagnosticsSink interface {
+	Report(diagnostic Diagnostic)
+}
+
+// DiagnosticsFunc adapts a function to a DiagnosticsSink
+//
+//	ex: ParseOptions{Diagnostics: DiagnosticsFunc(func(d Diagnostic) { warnings = append(warnings, d) })}
+type DiagnosticsFunc func(diagnostic Diagnostic)
+
+func (f DiagnosticsFunc) Report(diagnostic Diagnostic) {
+	f(diagnostic)
+}
+
+// Returns a DiagnosticsSink that writes the diagnostics to the given slog handler
+// Infos are logged at slog.LevelInfo, warnings at slog.LevelWarn and errors at slog.LevelError
+func NewSlogDiagnostics(handler slog.Handler) DiagnosticsSink {
+	return slogDiagnostics{logger: slog.New(handler)}
+}
+
+type slogDiagnostics struct {
+	logger *slog.Logger
+}
+
+func (sink slogDiagnostics) Report(diagnostic Diagnostic) {
+	level := slog.LevelWarn
+	switch diagnostic.Severity {
+	case SEVERITY_INFO:
+		level = slog.LevelInfo
+	case SEVERITY_ERROR:
+		level = slog.LevelError
+	}
+	sink.logger.Log(context.Background(), level, diagnostic.Message,
+		slog.String("code", string(diagnostic.Code)),
+		slog.String("constraint", diagnostic.Constraint),
+		slog.Int("offset", diagnostic.Offset),
+		slog.String("literal", diagnostic.Literal),
+	)
+}
+
+// ParseOptions configure ParseConstraintWithOptions
+// The zero value parses like ParseConstraint
+type ParseOptions struct {
+	// The grammar of the constraint, nodejs if empty or not a built-in ecosystem
+	// Ecosystems registered with semver.RegisterEcosystem are not known here, use semver.ParseConstraintWithEcosystem for those
+	// Composer constraints join comparators by a comma or whitespace (and) and by a single or double pipe (or),
+	// and follow the tilde, caret, wildcard and stability flag semantics of Composer, e.g. ~1.2 := >=1.2.0-0 <2.0.0-0
+	Ecosystem string
+	// Receives the diagnostics found while parsing, nil drops them
+	Diagnostics DiagnosticsSink
+	// Normalizes forms that npm accepts in loose mode, e.g. =v1.2.3, v 1.2.3, 1.2.3beta, ==1.0 and trailing carriage returns
+	// Each rewrite is reported as an INFO_LOOSE_REWRITE diagnostic, offsets of diagnostics and errors refer to the original constraint
+	// Composer constraints are not rewritten
+	Loose bool
+}
+
+func (options ParseOptions) report(diagnostic Diagnostic) {
+	if options.Diagnostics != nil {
+		options.Diagnostics.Report(diagnostic)
+	}
+}
+
+// Reports the error as a diagnostic, if it is a ParseError
+func (options ParseOptions) reportError(constraintString string, err error) {
+	if parseError, ok := err.(*ParseError); ok {
+		options.report(Diagnostic{
+			Severity:   SEVERITY_ERROR,
+			Code:       DiagnosticCode(parseError.Code),
+			Constraint: constraintString,
+			Offset:     parseError.Offset,
+			Literal:    parseError.Literal,
+			Message:    parseError.Error(),
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package constraints
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidConstraint            = errors.New("invalid Semantic Version")
+	ErrEmptyConstraint              = errors.New("empty constraint")
+	ErrIllegalCharacterInConstraint = errors.New("illegal character in constraint")
+	ErrInvalidVersion               = errors.New("invalid Version")
+	ErrConstraintTooComplex         = errors.New("constraint too complex")
+)
+
+// A ParseErrorCode identifies the kind of a ParseError, it is stable and can be relied upon by callers
+type ParseErrorCode string
+
+const (
+	ERR_ILLEGAL_TOKEN          ParseErrorCode = "illegal_token"
+	ERR_UNEXPECTED_TOKEN       ParseErrorCode = "unexpected_token"
+	ERR_UNBALANCED_PARENTHESIS ParseErrorCode = "unbalanced_parenthesis"
+	ERR_EMPTY_GROUP            ParseErrorCode = "empty_group"
+	ERR_MISPLACED_JOIN         ParseErrorCode = "misplaced_join"
+	ERR_MISSING_JOIN           ParseErrorCode = "missing_join"
+	ERR_MISSING_OPERATOR       ParseErrorCode = "missing_operator"
+	ERR_MISSING_VERSION        ParseErrorCode = "missing_version"
+	ERR_CONSECUTIVE_OPERATORS  ParseErrorCode = "consecutive_operators"
+	ERR_CONTRADICTING_VERSIONS ParseErrorCode = "contradicting_versions"
+	ERR_INVALID_EXCLUSION      ParseErrorCode = "invalid_exclusion"
+	ERR_INVALID_HYPHEN_RANGE   ParseErrorCode = "invalid_hyphen_range"
+	ERR_INVALID_RANGE          ParseErrorCode = "invalid_range"
+	ERR_INVALID_VERSION        ParseErrorCode = "invalid_version"
+	ERR_TOO_COMPLEX            ParseErrorCode = "too_complex"
+)
+
+// A TokenClass describes a group of tokens that the parser expected to find
+type TokenClass string
+
+const (
+	VERSION_CLASS           TokenClass = "version"
+	STATIC_VERSION_CLASS    TokenClass = "static version"
+	RANGE_OPERATOR_CLASS    TokenClass = "range operator"
+	EQUALITY_OPERATOR_CLASS TokenClass = "equality operator"
+	JOIN_OPERATOR_CLASS     TokenClass = "join operator"
+	OPEN_PARENTHESIS_CLASS  TokenClass = "opening parenthesis"
+	CLOSE_PARENTHESIS_CLASS TokenClass = "closing parenthesis"
+	END_CLASS               TokenClass = "end of constraint"
+)
+
+// A ParseError is returned for constraints that cannot be parsed
+// It wraps one of the sentinel errors, such that it can be matched with errors.Is
+// Every ParseError wraps ErrInvalidConstraint, such that any parse failure can be matched with a single check
+//
+//	ex: errors.Is(err, constraints.ErrInvalidConstraint)
+//	ex: errors.Is(err, constraints.ErrIllegalCharacterInConstraint)
+//
+// Errors caused by an invalid version additionally wrap the error of the version parser.
+type ParseError struct {
+	// Byte offset of the offending token within the constraint
+	Offset int
+	// The offending token as it appears in the constraint, empty at the end of the constraint
+	Literal string
+	Code    ParseErrorCode
+	// The classes of tokens that would have been valid in place of the offending token, if known
+	Expected []TokenClass
+	Message  string
+	Err      error
+	Cause    error
+}
+
+func (e *ParseError) Error() string {
+	where := fmt.Sprintf("at offset %d", e.Offset)
+	if e.Literal != "" {
+		where = fmt.Sprintf("%s ('%s')", where, e.Literal)
+	}
+
+	message := fmt.Sprintf("invalid Version Constraint: %s %s", strings.TrimSuffix(e.Message, "."), where)
+	if len(e.Expected) > 0 {
+		expected := []string{}
+		for _, class := range e.Expected {
+			expected = append(expected, string(class))
+		}
+		message = fmt.Sprintf("%s, expected %s", message, strings.Join(expected, " or "))
+	}
+	if e.Cause != nil {
+		message = fmt.Sprintf("%s: %s", message, e.Cause)
+	}
+	return message
+}
+
+func (e *ParseError) Unwrap() []error {
+	unwrapped := []error{e.Err}
+	if e.Err != ErrInvalidConstraint {
+		unwrapped = append(unwrapped, ErrInvalidConstraint)
+	}
+	if e.Cause != nil {
+		unwrapped = append(unwrapped, e.Cause)
+	}
+	return unwrapped
+}
+
+// Returns a ParseError for the token at the given position of the token list
+func newParseError(sentinel error, code ParseErrorCode, message string, literals []string, offsets []int, position int, expected ...TokenClass) *ParseError {
+	return &ParseError{
+		Offset:   offsets[position],
+		Literal:  literals[position],
+		Code:     code,
+		Expected: expected,
+		Message:  message,
+		Err:      sentinel,
+	}
+}
+
+func newErrInvalidConstraint(code ParseErrorCode, message string, literals []string, offsets []int, position int, expected ...TokenClass) *ParseError {
+	return newParseError(ErrInvalidConstraint, code, message, literals, offsets, position, expected...)
+}
@@ -0,0 +1,159 @@
+package constraints
+
+import (
+	"strings"
+)
+
+type ExpressionType string
+
+const (
+	RANGE_EXPRESSION ExpressionType = "range"
+	AND_EXPRESSION   ExpressionType = "and"
+	OR_EXPRESSION    ExpressionType = "or"
+)
+
+// An Expression is a node of the expression tree of a constraint
+// A RANGE_EXPRESSION is a leaf holding a desugared range, AND_EXPRESSION and OR_EXPRESSION nodes join their operands
+//
+//	ex: (>=1.0.0 <1.5.0 || >=2.0.0) && <2.3.0 := and(or(range, range), range)
+type Expression struct {
+	Type     ExpressionType
+	Range    Range
+	Operands []Expression
+}
+
+// Returns the leaf expression of a desugared range
+func NewRangeExpression(r Range) Expression {
+	return Expression{Type: RANGE_EXPRESSION, Range: r}
+}
+
+// Returns the expression joining the operands by the given join type
+// Operands of the same type are flattened, e.g. a && (b && c) := a && b && c, and a single operand is returned as is
+func newJoinExpression(joinType ExpressionType, operands []Expression) Expression {
+	flattened := []Expression{}
+	for _, operand := range operands {
+		if operand.Type == joinType {
+			flattened = append(flattened, operand.Operands...)
+		} else {
+			flattened = append(flattened, operand)
+		}
+	}
+	if len(flattened) == 1 {
+		return flattened[0]
+	}
+	return Expression{Type: joinType, Operands: flattened}
+}
+
+// The maximum number of ranges in the disjunctive normal form of a constraint
+// Distributing conjunctions over disjunctions grows exponentially, e.g. 20 groups of (1.x || 2.x) && expand into 20 * 2^20 ranges,
+// so constraints whose normal form exceeds this number are rejected rather than expanded
+const MaxNormalFormRanges = 10000
+
+// Returns the number of ranges and the number of comparator sets of the disjunctive normal form of the expression, without expanding it
+// Both numbers are capped at MaxNormalFormRanges + 1, such that counting cannot overflow
+//
+//	ex: (1.x || 2.x) && <1.5.0 := 4 ranges, 2 sets
+func (e Expression) normalFormSize() (int, int) {
+	capped := func(n int) int {
+		return min(n, MaxNormalFormRanges+1)
+	}
+
+	switch e.Type {
+	case AND_EXPRESSION:
+		ranges, sets := 0, 1
+		for _, operand := range e.Operands {
+			operandRanges, operandSets := operand.normalFormSize()
+			// Each set of the product joins a set of the conjunction so far with a set of the operand
+			ranges = capped(ranges*operandSets + operandRanges*sets)
+			sets = capped(sets * operandSets)
+		}
+		return ranges, sets
+	case OR_EXPRESSION:
+		ranges, sets := 0, 0
+		for _, operand := range e.Operands {
+			operandRanges, operandSets := operand.normalFormSize()
+			ranges = capped(ranges + operandRanges)
+			sets = capped(sets + operandSets)
+		}
+		return ranges, sets
+	default:
+		return 1, 1
+	}
+}
+
+// Returns the disjunctive normal form of the expression: a disjunction of comparator sets, each being a conjunction of ranges
+//
+//	ex: (1.x || 2.x) && <1.5.0 := 1.x && <1.5.0 || 2.x && <1.5.0
+func (e Expression) disjunctiveNormalForm() [][]Range {
+	switch e.Type {
+	case AND_EXPRESSION:
+		sets := [][]Range{{}}
+		for _, operand := range e.Operands {
+			product := [][]Range{}
+			for _, set := range sets {
+				for _, operandSet := range operand.disjunctiveNormalForm() {
+					combined := append(append([]Range{}, set...), operandSet...)
+					product = append(product, combined)
+				}
+			}
+			sets = product
+		}
+		return sets
+	case OR_EXPRESSION:
+		sets := [][]Range{}
+		for _, operand := range e.Operands {
+			sets = append(sets, operand.disjunctiveNormalForm()...)
+		}
+		return sets
+	default:
+		return [][]Range{{e.Range}}
+	}
+}
+
+// Returns the expression in constraint syntax, groups are wrapped in parentheses where the precedence requires it
+//
+//	ex: (>=1.0.0 <1.5.0 || >=2.0.0) && <2.3.0
+func (e Expression) String() string {
+	switch e.Type {
+	case AND_EXPRESSION, OR_EXPRESSION:
+		separator := " && "
+		if e.Type == OR_EXPRESSION {
+			separator = " || "
+		}
+		formatted := []string{}
+		for _, operand := range e.Operands {
+			// Conjunctions bind stronger than disjunctions, so only disjunctions within conjunctions need parentheses
+			if e.Type == AND_EXPRESSION && operand.Type == OR_EXPRESSION {
+				formatted = append(formatted, "("+operand.String()+")")
+			} else {
+				formatted = append(formatted, operand.String())
+			}
+		}
+		return strings.Join(formatted, separator)
+	default:
+		return e.Range.String()
+	}
+}
+
+// Returns the expression tree of the constraint
+// Constraints that were not parsed, but built from ranges and join operators, are converted into a disjunction of conjunctions
+func (c Constraint) ExpressionTree() Expression {
+	if c.Expression != nil {
+		return *c.Expression
+	}
+
+	disjunction := []Expression{}
+	conjunction := []Expression{}
+	for idx, r := range c.Ranges {
+		conjunction = append(conjunction, NewRangeExpression(r))
+		if idx >= len(c.Join) || c.Join[idx] == DISJUNCTION {
+			disjunction = append(disjunction, newJoinExpression(AND_EXPRESSION, conjunction))
+			conjunction = []Expression{}
+		}
+	}
+	if len(conjunction) > 0 {
+		disjunction = append(disjunction, newJoinExpression(AND_EXPRESSION, conjunction))
+	}
+
+	return newJoinExpression(OR_EXPRESSION, disjunction)
+}
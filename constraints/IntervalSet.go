@@ -0,0 +1,506 @@
+package constraints
+
+import (
+	"sort"
+	"strings"
+
+	version "github.com/CodeClarityCE/utility-node-semver/versions"
+)
+
+type BoundType string
+
+const (
+	UNBOUNDED BoundType = "unbounded"
+	INCLUSIVE BoundType = "inclusive"
+	EXCLUSIVE BoundType = "exclusive"
+)
+
+// A Bound is one end of an interval of versions
+// An unbounded lower end stretches to the lowest possible version, an unbounded upper end to infinity.
+// The version of an unbounded end is meaningless, so 0.0.0 can be used as a real bound.
+type Bound struct {
+	Type    BoundType
+	Version version.Semver
+}
+
+// An Interval is a contiguous set of versions between a lower and an upper bound
+type Interval struct {
+	Lower Bound
+	Upper Bound
+}
+
+// An IntervalSet is the canonical form of a constraint: a list of sorted and disjoint intervals
+// Two intervals of the set never touch, e.g. [1.0.0, 2.0.0) and [2.0.0, 3.0.0) are stored as [1.0.0, 3.0.0)
+type IntervalSet []Interval
+
+// Returns the interval matching every numbered version, unaliased branches such as dev-main are not part of it
+func AnyInterval() Interval {
+	return Interval{Lower: Bound{Type: UNBOUNDED}, Upper: Bound{Type: UNBOUNDED}}
+}
+
+// Returns the interval described by a single comparator, e.g. >= 1.0.0
+// An empty operator imposes no restriction
+func comparatorInterval(op Token, v version.Semver) Interval {
+	i := AnyInterval()
+	switch op {
+	case GE:
+		i.Lower = Bound{Type: INCLUSIVE, Version: v}
+	case GT:
+		i.Lower = Bound{Type: EXCLUSIVE, Version: v}
+	case LE:
+		i.Upper = Bound{Type: INCLUSIVE, Version: v}
+	case LT:
+		i.Upper = Bound{Type: EXCLUSIVE, Version: v}
+	case EQ:
+		i.Lower = Bound{Type: INCLUSIVE, Version: v}
+		i.Upper = Bound{Type: INCLUSIVE, Version: v}
+	}
+	return i
+}
+
+// Returns the interval of versions allowed by a desugared range
+// Whether the range is bounded is only decided by its operators, such that >= 0.0.0 <1.0.0 is a bounded range,
+// except for a lone >= 0.0.0, which is how * desugars, and allows any version
+// An exclusion (!=) is not contiguous and imposes no restriction on the interval, use IntervalSet instead
+func (r Range) Interval() Interval {
+	if r.StartOp == GE && r.EndOp == "" && r.StartVersion.EQ(version.Semver{}, false) {
+		return AnyInterval()
+	}
+	i := comparatorInterval(r.StartOp, r.StartVersion)
+	if r.EndOp != "" {
+		i = i.Intersect(comparatorInterval(r.EndOp, r.EndVersion))
+	}
+	return i
+}
+
+// Returns the set of versions allowed by a desugared range
+//
+//	ex: != 1.3.2 := (, 1.3.2) ∪ (1.3.2, )
+func (r Range) IntervalSet() IntervalSet {
+	if r.StartOp == NOT {
+		return NewIntervalSet(comparatorInterval(LT, r.StartVersion), comparatorInterval(GT, r.StartVersion))
+	}
+	return NewIntervalSet(r.Interval())
+}
+
+// Compares two lower bounds, returns -1 if b1 starts before b2, 1 if b1 starts after b2 and 0 if they are equal
+func compareLower(b1 Bound, b2 Bound) int {
+	if b1.Type == UNBOUNDED || b2.Type == UNBOUNDED {
+		return compareUnbounded(b1, b2, -1)
+	}
+	if cmp := b1.Version.Compare(b2.Version, false); cmp != 0 {
+		return cmp
+	}
+	// [1.0.0 starts before (1.0.0
+	return compareInclusive(b1, b2, -1)
+}
+
+// Compares two upper bounds, returns -1 if b1 ends before b2, 1 if b1 ends after b2 and 0 if they are equal
+func compareUpper(b1 Bound, b2 Bound) int {
+	if b1.Type == UNBOUNDED || b2.Type == UNBOUNDED {
+		return compareUnbounded(b1, b2, 1)
+	}
+	if cmp := b1.Version.Compare(b2.Version, false); cmp != 0 {
+		return cmp
+	}
+	// 1.0.0) ends before 1.0.0]
+	return compareInclusive(b1, b2, 1)
+}
+
+func compareUnbounded(b1 Bound, b2 Bound, unboundedSide int) int {
+	if b1.Type == b2.Type {
+		return 0
+	}
+	if b1.Type == UNBOUNDED {
+		return unboundedSide
+	}
+	return -unboundedSide
+}
+
+func compareInclusive(b1 Bound, b2 Bound, inclusiveSide int) int {
+	if b1.Type == b2.Type {
+		return 0
+	}
+	if b1.Type == INCLUSIVE {
+		return inclusiveSide
+	}
+	return -inclusiveSide
+}
+
+// Returns true if the bound is a version with a prerelease tag and the same [major, minor, patch] tuple as v
+func (b Bound) HasPreReleaseOf(v version.Semver) bool {
+	return b.Type != UNBOUNDED && b.Version.PreReleaseTag != "" && b.Version.EQ(v, true)
+}
+
+// Returns the bound that starts right after the upper bound b, e.g. 2.0.0) := [2.0.0
+// or the bound that ends right before the lower bound b, e.g. [2.0.0 := 2.0.0)
+func oppositeBound(b Bound) Bound {
+	if b.Type == INCLUSIVE {
+		return Bound{Type: EXCLUSIVE, Version: b.Version}
+	}
+	return Bound{Type: INCLUSIVE, Version: b.Version}
+}
+
+// Returns 0.0.0-0, the lowest possible version
+func lowestVersion() version.Semver {
+	return version.Semver{PreReleaseTag: "0"}
+}
+
+// Returns an interval that does not contain any version
+func emptyInterval() Interval {
+	return Interval{Lower: Bound{Type: UNBOUNDED}, Upper: Bound{Type: EXCLUSIVE, Version: lowestVersion()}}
+}
+
+// Returns the name of the unaliased branch the interval holds, e.g. main for =dev-main, or an empty string
+// A branch is not a numbered version, so it only appears as a single version interval and lies within no other interval
+func (i Interval) branch() string {
+	if i.Lower.Type == UNBOUNDED || !i.Lower.Version.IsUnaliasedBranch() {
+		return ""
+	}
+	return i.Lower.Version.DevBranch
+}
+
+// Returns true if the interval does not contain any version
+func (i Interval) IsEmpty() bool {
+	// 0.0.0-0 is the lowest possible version, nothing lies below it
+	if i.Upper.Type == EXCLUSIVE && i.Upper.Version.EQ(lowestVersion(), false) {
+		return true
+	}
+	if i.Lower.Type == UNBOUNDED || i.Upper.Type == UNBOUNDED {
+		return false
+	}
+	cmp := i.Lower.Version.Compare(i.Upper.Version, false)
+	if cmp != 0 {
+		return cmp > 0
+	}
+	return i.Lower.Type == EXCLUSIVE || i.Upper.Type == EXCLUSIVE
+}
+
+// Returns true if the interval contains exactly one version
+func (i Interval) IsSingleVersion() bool {
+	return i.Lower.Type == INCLUSIVE && i.Upper.Type == INCLUSIVE && i.Lower.Version.EQ(i.Upper.Version, false)
+}
+
+// Returns true if the version lies within the interval, prerelease versions are ordered as per the semver spec
+func (i Interval) Contains(v version.Semver) bool {
+	return !i.Intersect(comparatorInterval(EQ, v)).IsEmpty()
+}
+
+// Returns true if every version of the interval is lower than v
+func (i Interval) EndsBefore(v version.Semver) bool {
+	return !i.IsEmpty() && compareUpper(i.Upper, Bound{Type: INCLUSIVE, Version: v}) < 0
+}
+
+// Returns true if every version of the interval is greater than v
+func (i Interval) StartsAfter(v version.Semver) bool {
+	return !i.IsEmpty() && compareLower(i.Lower, Bound{Type: INCLUSIVE, Version: v}) > 0
+}
+
+// Returns true if the interval i covers all versions of other
+func (i Interval) Covers(other Interval) bool {
+	return i.branch() == other.branch() && compareLower(i.Lower, other.Lower) <= 0 && compareUpper(i.Upper, other.Upper) >= 0
+}
+
+// Returns the versions contained in both intervals, the result might be empty
+func (i Interval) Intersect(other Interval) Interval {
+	// Branches are ordered above every numbered version, but >=1.0.0 does not allow dev-main
+	if i.branch() != other.branch() {
+		return emptyInterval()
+	}
+	result := i
+	if compareLower(other.Lower, i.Lower) > 0 {
+		result.Lower = other.Lower
+	}
+	if compareUpper(other.Upper, i.Upper) < 0 {
+		result.Upper = other.Upper
+	}
+	return result
+}
+
+// Returns true if the interval other, which must not start before i, overlaps or touches i
+// so that both can be merged into a single contiguous interval
+//
+//	ex: [1.0.0, 2.0.0) and [2.0.0, 3.0.0) touch, [1.0.0, 2.0.0) and (2.0.0, 3.0.0) do not
+func (i Interval) touches(other Interval) bool {
+	if i.branch() != other.branch() {
+		return false
+	}
+	if i.Upper.Type == UNBOUNDED || other.Lower.Type == UNBOUNDED {
+		return true
+	}
+	cmp := other.Lower.Version.Compare(i.Upper.Version, false)
+	if cmp != 0 {
+		return cmp < 0
+	}
+	return i.Upper.Type == INCLUSIVE || other.Lower.Type == INCLUSIVE
+}
+
+// Returns the desugared range of an interval
+// Following the parser, a range with a single operator stores it as its start
+func (i Interval) Range() Range {
+	r := Range{}
+
+	if i.IsSingleVersion() {
+		r.StartOp = EQ
+		r.StartVersion = i.Lower.Version
+		return r
+	}
+
+	if i.Lower.Type == UNBOUNDED && i.Upper.Type == UNBOUNDED {
+		// As desugared from *
+		r.StartOp = GE
+		r.StartVersion = version.Semver{}
+		return r
+	}
+
+	if i.Lower.Type != UNBOUNDED {
+		r.StartOp = GT
+		if i.Lower.Type == INCLUSIVE {
+			r.StartOp = GE
+		}
+		r.StartVersion = i.Lower.Version
+	}
+
+	if i.Upper.Type != UNBOUNDED {
+		op := LT
+		if i.Upper.Type == INCLUSIVE {
+			op = LE
+		}
+		if i.Lower.Type == UNBOUNDED {
+			r.StartOp = op
+			r.StartVersion = i.Upper.Version
+		} else {
+			r.EndOp = op
+			r.EndVersion = i.Upper.Version
+		}
+	}
+
+	return r
+}
+
+// Returns the interval in constraint syntax, e.g. >=1.0.0 <2.0.0-0, or * if it is unbounded
+func (i Interval) String() string {
+	if i.Lower.Type == UNBOUNDED && i.Upper.Type == UNBOUNDED {
+		return "*"
+	}
+	return i.Range().String()
+}
+
+// Returns the canonical set of the given intervals
+// Empty intervals are dropped, the others are sorted and merged if they overlap or touch
+func NewIntervalSet(intervals ...Interval) IntervalSet {
+	nonEmpty := []Interval{}
+	for _, i := range intervals {
+		if i.IsEmpty() {
+			continue
+		}
+		// Nothing lies below 0.0.0-0, so starting at it is the same as having no lower bound, e.g. >=0.0.0-0 := *
+		if i.Lower.Type == INCLUSIVE && i.Lower.Version.EQ(lowestVersion(), false) {
+			i.Lower = Bound{Type: UNBOUNDED}
+		}
+		nonEmpty = append(nonEmpty, i)
+	}
+
+	sort.SliceStable(nonEmpty, func(a, b int) bool {
+		return compareLower(nonEmpty[a].Lower, nonEmpty[b].Lower) < 0
+	})
+
+	set := IntervalSet{}
+	for _, i := range nonEmpty {
+		if len(set) > 0 && set[len(set)-1].touches(i) {
+			last := &set[len(set)-1]
+			if compareUpper(i.Upper, last.Upper) > 0 {
+				last.Upper = i.Upper
+			}
+			continue
+		}
+		set = append(set, i)
+	}
+	return set
+}
+
+// Returns true if the set does not contain any version
+func (s IntervalSet) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// Returns the interval of the set that contains the version, if any
+func (s IntervalSet) Find(v version.Semver) (Interval, bool) {
+	for _, i := range s {
+		if i.Contains(v) {
+			return i, true
+		}
+	}
+	return Interval{}, false
+}
+
+// Returns the interval of the set that covers all versions of other, if any
+// Since the intervals of a set are disjoint and do not touch, a contiguous interval can only be covered by one of them
+func (s IntervalSet) FindCovering(other Interval) (Interval, bool) {
+	for _, i := range s {
+		if i.Covers(other) {
+			return i, true
+		}
+	}
+	return Interval{}, false
+}
+
+// Returns the set of versions contained in both sets
+func (s IntervalSet) Intersect(other IntervalSet) IntervalSet {
+	intersection := []Interval{}
+	for _, i := range s {
+		for _, o := range other {
+			intersection = append(intersection, i.Intersect(o))
+		}
+	}
+	return NewIntervalSet(intersection...)
+}
+
+// Returns the set of versions contained in either set
+func (s IntervalSet) Union(other IntervalSet) IntervalSet {
+	return NewIntervalSet(append(append([]Interval{}, s...), other...)...)
+}
+
+// Returns the set of versions not contained in the set
+// Branches cannot be enumerated, so the complement only holds numbered versions
+func (s IntervalSet) Complement() IntervalSet {
+	complement := []Interval{}
+	lower := Bound{Type: UNBOUNDED}
+
+	for _, i := range s {
+		if i.branch() != "" {
+			continue
+		}
+		if i.Lower.Type != UNBOUNDED {
+			complement = append(complement, Interval{Lower: lower, Upper: oppositeBound(i.Lower)})
+		}
+		if i.Upper.Type == UNBOUNDED {
+			return NewIntervalSet(complement...)
+		}
+		lower = oppositeBound(i.Upper)
+	}
+
+	complement = append(complement, Interval{Lower: lower, Upper: Bound{Type: UNBOUNDED}})
+	return NewIntervalSet(complement...)
+}
+
+// Returns true if both sets contain exactly the same versions
+func (s IntervalSet) Equal(other IntervalSet) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for idx, i := range s {
+		if compareLower(i.Lower, other[idx].Lower) != 0 || compareUpper(i.Upper, other[idx].Upper) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns a constraint matching exactly the versions of the set, as a disjunction of ranges in ascending order
+// The constraint of an empty set is <0.0.0-0, which cannot be satisfied since no version is lower than 0.0.0-0
+func (s IntervalSet) Constraint() Constraint {
+	constraint := Constraint{Ranges: []Range{}, Join: []JoinOp{}}
+
+	if s.IsEmpty() {
+		constraint.Ranges = append(constraint.Ranges, Range{StartOp: LT, StartVersion: lowestVersion()})
+	}
+
+	for idx, i := range s {
+		if idx > 0 {
+			constraint.Join = append(constraint.Join, DISJUNCTION)
+		}
+		constraint.Ranges = append(constraint.Ranges, i.Range())
+	}
+
+	constraint.Original = s.String()
+	return constraint
+}
+
+// Returns the set in constraint syntax, e.g. >=1.0.0 <1.2.0 || >=1.4.5 <2.0.0-0
+func (s IntervalSet) String() string {
+	if s.IsEmpty() {
+		return "<0.0.0-0"
+	}
+	formatted := []string{}
+	for _, i := range s {
+		formatted = append(formatted, i.String())
+	}
+	return strings.Join(formatted, " || ")
+}
+
+// Returns the canonical set of versions allowed by the constraint
+//
+// Conjunctions bind stronger than disjunctions:
+//
+//	ex: 4.0.0 || 5.x && < 5.5.0 := [4.0.0, 4.0.0] ∪ [5.0.0, 5.5.0)
+func (c Constraint) IntervalSet() IntervalSet {
+	return c.ExpressionTree().IntervalSet()
+}
+
+// Returns the canonical set of versions allowed by the expression
+// The set is computed from the tree, such that groups are never distributed into their disjunctive normal form
+//
+//	ex: (1.x || 2.x) && <1.5.0 := ([1.0.0, 2.0.0-0) ∪ [2.0.0, 3.0.0-0)) ∩ [0.0.0, 1.5.0) := [1.0.0, 1.5.0)
+func (e Expression) IntervalSet() IntervalSet {
+	switch e.Type {
+	case AND_EXPRESSION:
+		// Starting from the first operand rather than any version, since any version does not include the branches
+		set := e.Operands[0].IntervalSet()
+		for _, operand := range e.Operands[1:] {
+			set = set.Intersect(operand.IntervalSet())
+		}
+		return set
+	case OR_EXPRESSION:
+		set := NewIntervalSet()
+		for _, operand := range e.Operands {
+			set = set.Union(operand.IntervalSet())
+		}
+		return set
+	default:
+		return e.Range.IntervalSet()
+	}
+}
+
+// Returns the sets of versions allowed by each comparator set of the constraint, i.e. each conjunction of its ranges
+//
+//	ex: 4.0.0 || 5.x && < 5.5.0 := [[4.0.0, 4.0.0]], [[5.0.0, 5.5.0)]
+func (c Constraint) comparatorSets() []IntervalSet {
+	sets := []IntervalSet{}
+	current := IntervalSet{}
+
+	for idx, r := range c.Ranges {
+		// Each comparator set starts from its first range, as in Expression.IntervalSet
+		if idx == 0 || c.Join[idx-1] == DISJUNCTION {
+			current = r.IntervalSet()
+		} else {
+			current = current.Intersect(r.IntervalSet())
+		}
+
+		if idx >= len(c.Join) || c.Join[idx] == DISJUNCTION {
+			sets = append(sets, current)
+		}
+	}
+
+	return sets
+}
+
+// Returns a deterministic normalized representation of the constraint, which can be used as a map key
+// Two constraints have the same canonical form if and only if they are Equivalent
+//
+//	ex: ^1.0.0 := >=1.0.0 <2.0.0-0
+//	ex: >= 1.0.0 < 1.5.0 || ~1.4.2 := >=1.0.0 <1.5.0
+//	ex: 2.x || 1.x := >=1.0.0 <2.0.0-0 || >=2.0.0 <3.0.0-0
+//	ex: <1.0.0 || >=1.0.0 := *
+//
+// Build metadata does not take part in version precedence, so it is dropped.
+func (c Constraint) Canonical() string {
+	set := c.IntervalSet()
+	for idx := range set {
+		set[idx].Lower.Version.MetaData = ""
+		set[idx].Upper.Version.MetaData = ""
+		set[idx].Lower.Version.Raw = ""
+		set[idx].Upper.Version.Raw = ""
+	}
+	return set.String()
+}
@@ -0,0 +1,118 @@
+package constraints
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeClarityCE/utility-node-semver/versions"
+)
+
+type IntervalSetToTest struct {
+	ConstraintString string
+	ExpectedSet      IntervalSet
+}
+
+func TestConstraintIntervalSet(t *testing.T) {
+
+	fmt.Printf("\n%s Testing conversion of constraints to interval sets %s\n", "----------------", "----------------")
+
+	setsToTest := []IntervalSetToTest{
+		// A 0.0.0 bound is a real bound, not the absence of a bound
+		{
+			ConstraintString: "~0",
+			ExpectedSet: IntervalSet{
+				{
+					Lower: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 0, Minor: 0, Patch: 0}},
+					Upper: Bound{Type: EXCLUSIVE, Version: versions.Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "0"}},
+				},
+			},
+		},
+		{
+			ConstraintString: "< 2.0.0",
+			ExpectedSet: IntervalSet{
+				{
+					Lower: Bound{Type: UNBOUNDED},
+					Upper: Bound{Type: EXCLUSIVE, Version: versions.Semver{Major: 2, Minor: 0, Patch: 0}},
+				},
+			},
+		},
+		{
+			ConstraintString: "=1.2.3",
+			ExpectedSet: IntervalSet{
+				{
+					Lower: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 1, Minor: 2, Patch: 3}},
+					Upper: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 1, Minor: 2, Patch: 3}},
+				},
+			},
+		},
+		// Intervals are sorted
+		{
+			ConstraintString: "3.x || 1.x",
+			ExpectedSet: IntervalSet{
+				{
+					Lower: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 1, Minor: 0, Patch: 0}},
+					Upper: Bound{Type: EXCLUSIVE, Version: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"}},
+				},
+				{
+					Lower: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 3, Minor: 0, Patch: 0}},
+					Upper: Bound{Type: EXCLUSIVE, Version: versions.Semver{Major: 4, Minor: 0, Patch: 0, PreReleaseTag: "0"}},
+				},
+			},
+		},
+		// Overlapping and touching intervals are merged
+		{
+			ConstraintString: ">= 1.0.0 < 1.5.0 || >= 1.2.0 < 2.0.0 || >= 2.0.0 <= 2.5.0",
+			ExpectedSet: IntervalSet{
+				{
+					Lower: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 1, Minor: 0, Patch: 0}},
+					Upper: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 2, Minor: 5, Patch: 0}},
+				},
+			},
+		},
+		// Conjunctions bind stronger than disjunctions
+		{
+			ConstraintString: "4.0.0 || 5.x && < 5.5.0",
+			ExpectedSet: IntervalSet{
+				{
+					Lower: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 4, Minor: 0, Patch: 0}},
+					Upper: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 4, Minor: 0, Patch: 0}},
+				},
+				{
+					Lower: Bound{Type: INCLUSIVE, Version: versions.Semver{Major: 5, Minor: 0, Patch: 0}},
+					Upper: Bound{Type: EXCLUSIVE, Version: versions.Semver{Major: 5, Minor: 5, Patch: 0}},
+				},
+			},
+		},
+		// Unsatisfiable constraints have an empty set
+		{
+			ConstraintString: "5.2.x && 6.0.0",
+			ExpectedSet:      IntervalSet{},
+		},
+	}
+
+	for _, setToTest := range setsToTest {
+		fmt.Printf("\nTesting interval set of '%s'\n", setToTest.ConstraintString)
+
+		c, err := ParseConstraint(setToTest.ConstraintString)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", setToTest.ConstraintString, err)
+		}
+
+		set := c.IntervalSet()
+		if !set.Equal(setToTest.ExpectedSet) {
+			fmt.Printf("✗ Failed. Expected: '%s', but got: '%s'\n", setToTest.ExpectedSet.String(), set.String())
+			t.Errorf("✗ Failed. Expected: '%s', but got: '%s'\n", setToTest.ExpectedSet.String(), set.String())
+		} else {
+			fmt.Println("✓ Success")
+		}
+
+		// The constraint of an interval set converts back to the same interval set
+		if roundTrip := set.Constraint().IntervalSet(); !roundTrip.Equal(set) {
+			fmt.Printf("✗ Failed round trip. Expected: '%s', but got: '%s'\n", set.String(), roundTrip.String())
+			t.Errorf("✗ Failed round trip. Expected: '%s', but got: '%s'\n", set.String(), roundTrip.String())
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"io"
-	"log"
 	"strings"
 
 	versions "github.com/CodeClarityCE/utility-node-semver/versions"
@@ -17,6 +16,85 @@ func LexConstraint(constraint string) (tokens []Token, literals []string) {
 	return lexer.ScanWhole()
 }
 
+// Equivalent to LexConstraint, but additionally returns the byte offset of each token within the constraint
+// Tokens that are not part of the constraint, such as an augmented equality operator, share the offset of the token they precede
+func lexConstraint(constraint string) (tokens []Token, literals []string, offsets []int) {
+	lexer := newLexer(strings.NewReader(constraint))
+	return lexer.scanWholeWithOffsets()
+}
+
+// Equivalent to lexConstraint, but the forms that npm accepts in loose mode are rewritten into their regular form
+// The offsets of the rewrites refer to the constraint
+//
+//	ex: ==v 1.2.3beta\r := = 1.2.3-beta
+func lexLooseConstraint(constraint string) (tokens []Token, literals []string, offsets []int, rewrites []versions.Rewrite) {
+	rewrites = []versions.Rewrite{}
+
+	// Carriage returns, e.g. of manifests with windows line endings, are replaced by spaces such that the offsets stay the same
+	whitespace := []rune{}
+	for offset, ch := range constraint {
+		if ch == '\r' || ch == '\v' || ch == '\f' {
+			rewrites = append(rewrites, versions.Rewrite{Offset: offset, Original: string(ch), Replacement: " ", Message: "replaced control character with whitespace"})
+			ch = ' '
+		}
+		whitespace = append(whitespace, ch)
+	}
+
+	lexer := newLexer(strings.NewReader(string(whitespace)))
+	tokens, literals, offsets = lexer.scanTokens()
+	tokens, literals, offsets, tokenRewrites := loosenTokens(constraint, tokens, literals, offsets)
+	tokens, literals, offsets = trimTokens(tokens, literals, offsets)
+
+	rewrites = append(rewrites, tokenRewrites...)
+	slices.SortStableFunc(rewrites, func(a versions.Rewrite, b versions.Rewrite) int { return a.Offset - b.Offset })
+	return tokens, literals, offsets, rewrites
+}
+
+// Rewrites the loose forms of operators and versions, see lexLooseConstraint
+func loosenTokens(constraint string, tokens []Token, literals []string, offsets []int) ([]Token, []string, []int, []versions.Rewrite) {
+	tokensToReturn := []Token{}
+	literalsToReturn := []string{}
+	offsetsToReturn := []int{}
+	rewrites := []versions.Rewrite{}
+
+	for idx, token := range tokens {
+		literal := literals[idx]
+
+		switch {
+		// e.g. ==1.0 := =1.0
+		case token == ILLEGAL && literal == "==":
+			rewrites = append(rewrites, versions.Rewrite{Offset: offsets[idx], Original: literal, Replacement: "=", Message: "replaced '==' with '='"})
+			token = EQ
+			literal = "="
+
+		// e.g. v 1.2.3 := 1.2.3
+		case token == VERSION_EXPRESSION && (literal == "v" || literal == "V") && tokens[idx+1] == VERSION_EXPRESSION:
+			rewrites = append(rewrites, versions.Rewrite{Offset: offsets[idx], Original: constraint[offsets[idx]:offsets[idx+1]], Replacement: "", Message: "removed prefix"})
+			continue
+
+		// e.g. V1.2.3 := 1.2.3 and 1.2.3beta := 1.2.3-beta
+		case token == VERSION_EXPRESSION:
+			normalized, versionRewrites := versions.NormalizeLoose(literal)
+			for _, rewrite := range versionRewrites {
+				rewrite.Offset += offsets[idx]
+				rewrites = append(rewrites, rewrite)
+			}
+			// The single 'v' kept by NormalizeLoose is accepted by the version parser, but not by the range parsers, e.g. ^v1.2.3 := ^1.2.3
+			// As a version would accept it, it is not reported either
+			if len(normalized) > 1 {
+				normalized = strings.TrimPrefix(normalized, "v")
+			}
+			literal = normalized
+		}
+
+		tokensToReturn = append(tokensToReturn, token)
+		literalsToReturn = append(literalsToReturn, literal)
+		offsetsToReturn = append(offsetsToReturn, offsets[idx])
+	}
+
+	return tokensToReturn, literalsToReturn, offsetsToReturn, rewrites
+}
+
 var operatorsStarts = []rune{'=', '<', '>', '!', '&', '|', '-', '^', '~', '(', ')'}
 var versionUnsafe = []rune{'=', '<', '>', '!', '&', '|', '^', '~', '(', ')'}
 
@@ -24,24 +102,44 @@ var versionUnsafe = []rune{'=', '<', '>', '!', '&', '|', '^', '~', '(', ')'}
 var eof = rune(0)
 
 type Lexer struct {
-	r *bufio.Reader
+	r        *bufio.Reader
+	position *lexerPosition
+}
+
+// The byte offset of the next rune to read, and the size of the last rune read so that it can be unread
+type lexerPosition struct {
+	offset   int
+	lastSize int
 }
 
 // NewScanner returns a new instance of Scanner.
 func newLexer(r io.Reader) Lexer {
-	return Lexer{r: bufio.NewReader(r)}
+	return Lexer{r: bufio.NewReader(r), position: &lexerPosition{}}
 }
 
 func (lexer Lexer) ScanWhole() (tokens []Token, literals []string) {
+	tokens, literals, _ = lexer.scanWholeWithOffsets()
+	return tokens, literals
+}
+
+func (lexer Lexer) scanWholeWithOffsets() (tokens []Token, literals []string, offsets []int) {
+	return trimTokens(lexer.scanTokens())
+}
+
+// Returns all tokens of the reader, including whitespace tokens, between SOF and EOF
+func (lexer Lexer) scanTokens() (tokens []Token, literals []string, offsets []int) {
 	tokens = []Token{SOF}
 	literals = []string{""}
+	offsets = []int{0}
 
 	for {
+		offset := lexer.position.offset
 		token, literal := lexer.ScanOne()
 
 		if token != WS {
 			tokens = append(tokens, token)
 			literals = append(literals, literal)
+			offsets = append(offsets, offset)
 		}
 
 		if token == EOF {
@@ -49,6 +147,11 @@ func (lexer Lexer) ScanWhole() (tokens []Token, literals []string) {
 		}
 	}
 
+	return tokens, literals, offsets
+}
+
+// Removes the whitespace tokens, and augments and removes operators, such that the tokens can be validated and parsed
+func trimTokens(tokens []Token, literals []string, offsets []int) ([]Token, []string, []int) {
 	// Trim the token list from prefix WS and suffix WS
 	// i.e. remove whitespace directly behind SOF and whitespace directly infront of EOF (if any)
 	if len(tokens) > 1 {
@@ -57,6 +160,8 @@ func (lexer Lexer) ScanWhole() (tokens []Token, literals []string) {
 			tokens = append([]Token{SOF}, tokens...)
 			literals = literals[2:]
 			literals = append([]string{""}, literals...)
+			offsets = offsets[2:]
+			offsets = append([]int{0}, offsets...)
 		}
 	}
 	if len(tokens) > 1 {
@@ -65,10 +170,11 @@ func (lexer Lexer) ScanWhole() (tokens []Token, literals []string) {
 			tokens = append(tokens, EOF)
 			literals = literals[0 : len(literals)-2]
 			literals = append(literals, "")
+			offsets = append(offsets[0:len(offsets)-2], offsets[len(offsets)-1])
 		}
 	}
 
-	return removeSuperfluousAndOp(augmentMissingEqualityOp(tokens, literals))
+	return removeSuperfluousAndOp(augmentMissingEqualityOp(tokens, literals, offsets))
 }
 
 func (lexer Lexer) ScanOne() (token Token, literal string) {
@@ -152,11 +258,16 @@ func (lexer Lexer) scanOperator() (tok Token, lit string) {
 		} else if !isOperatorStart(ch) {
 			lexer.unread()
 			break
+		} else if ch == '(' || ch == ')' {
+			// A parenthesis is an operator on its own, e.g. ||( := || (
+			if buf.Len() > 0 {
+				lexer.unread()
+			} else {
+				_, _ = buf.WriteRune(ch)
+			}
+			break
 		} else {
 			_, _ = buf.WriteRune(ch)
-			if ch == '(' || ch == ')' {
-				break
-			}
 		}
 	}
 
@@ -176,9 +287,8 @@ func (lexer Lexer) scanOperator() (tok Token, lit string) {
 		return LT, buf.String()
 	case ">":
 		return GT, buf.String()
-	case "!":
-		// return NOT, buf.String()
-		return ILLEGAL, buf.String()
+	case "!=":
+		return NOT, buf.String()
 	case "~":
 		return TILDE, buf.String()
 	case "^":
@@ -212,27 +322,31 @@ func isDigit(ch rune) bool { return (ch >= '0' && ch <= '9') }
 
 // unread places the previously read rune back on the reader.
 func (lexer Lexer) unread() {
-	err := lexer.r.UnreadRune()
-	if err != nil {
-		log.Printf("Error during unread rune: %s", err)
+	// Unreading fails only if nothing was read, e.g. at the end of the reader, in which case there is nothing to place back
+	if err := lexer.r.UnreadRune(); err != nil {
+		return
 	}
+	lexer.position.offset -= lexer.position.lastSize
 }
 
 func (lexer Lexer) read() rune {
-	ch, _, err := lexer.r.ReadRune()
+	ch, size, err := lexer.r.ReadRune()
 	if err != nil {
 		return eof
 	}
+	lexer.position.offset += size
+	lexer.position.lastSize = size
 	return ch
 }
 
-func removeSuperfluousAndOp(tokens []Token, literals []string) ([]Token, []string) {
+func removeSuperfluousAndOp(tokens []Token, literals []string, offsets []int) ([]Token, []string, []int) {
 
 	tokensToReturn := []Token{}
 	literalsToReturn := []string{}
+	offsetsToReturn := []int{}
 
 	if slices.Contains(tokens, ILLEGAL) {
-		return tokens, literals
+		return tokens, literals, offsets
 	}
 
 	for idx, token := range tokens {
@@ -249,7 +363,7 @@ func removeSuperfluousAndOp(tokens []Token, literals []string) ([]Token, []strin
 				prevPrevPrevToken = tokens[idx-3]
 			}
 
-			if IsRangeToken(prevPrevToken) && prevToken == VERSION_EXPRESSION && (len(tokens)-idx) >= 2 && (prevPrevPrevToken == EOF || IsJoinToken(prevPrevPrevToken)) {
+			if IsRangeToken(prevPrevToken) && prevToken == VERSION_EXPRESSION && (len(tokens)-idx) >= 3 && (prevPrevPrevToken == EOF || IsJoinToken(prevPrevPrevToken)) {
 
 				nextToken := tokens[idx+1]
 				nextNextToken := tokens[idx+2]
@@ -264,20 +378,22 @@ func removeSuperfluousAndOp(tokens []Token, literals []string) ([]Token, []strin
 
 		tokensToReturn = append(tokensToReturn, token)
 		literalsToReturn = append(literalsToReturn, literal)
+		offsetsToReturn = append(offsetsToReturn, offsets[idx])
 
 	}
 
-	return tokensToReturn, literalsToReturn
+	return tokensToReturn, literalsToReturn, offsetsToReturn
 
 }
 
-func augmentMissingEqualityOp(tokens []Token, literals []string) ([]Token, []string) {
+func augmentMissingEqualityOp(tokens []Token, literals []string, offsets []int) ([]Token, []string, []int) {
 
 	tokensToReturn := []Token{}
 	literalsToReturn := []string{}
+	offsetsToReturn := []int{}
 
 	if slices.Contains(tokens, ILLEGAL) {
-		return tokens, literals
+		return tokens, literals, offsets
 	}
 
 	for idx, token := range tokens {
@@ -297,12 +413,14 @@ func augmentMissingEqualityOp(tokens []Token, literals []string) ([]Token, []str
 		if token == VERSION_EXPRESSION && versions.IsStaticVersion(literal) && (!IsRangeToken(previousToken) && !IsEqualityToken(previousToken)) && previousToken != HYPHEN && nextToken != HYPHEN {
 			tokensToReturn = append(tokensToReturn, EQ)
 			literalsToReturn = append(literalsToReturn, "=")
+			offsetsToReturn = append(offsetsToReturn, offsets[idx])
 		}
 		tokensToReturn = append(tokensToReturn, token)
 		literalsToReturn = append(literalsToReturn, literal)
+		offsetsToReturn = append(offsetsToReturn, offsets[idx])
 
 	}
 
-	return tokensToReturn, literalsToReturn
+	return tokensToReturn, literalsToReturn, offsetsToReturn
 
 }
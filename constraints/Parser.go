@@ -3,7 +3,7 @@ package constraints
 import (
 	"errors"
 	"fmt"
-	"log"
+	"math"
 	"strconv"
 	"strings"
 
@@ -14,10 +14,8 @@ import (
 )
 
 var (
-	ErrInvalidConstraint            = errors.New("invalid Semantic Version")
-	ErrEmptyConstraint              = errors.New("empty constraint")
-	ErrIllegalCharacterInConstraint = errors.New("illegal character in constraint")
-	ErrInvalidVersion               = errors.New("invalid Version")
+	errUnknownSubConstraint = errors.New("unknown sub constraint type")
+	errInvalidRange         = errors.New("invalid range")
 )
 
 type Range struct {
@@ -38,85 +36,123 @@ type SubConstraint struct {
 	Ranges Range
 }
 
+// A Constraint holds the expression tree of a parsed constraint, as well as its disjunctive normal form:
+// a list of ranges joined by conjunctions and disjunctions, where conjunctions bind stronger than disjunctions
+//
+//	ex: (1.x || 2.x) && <1.5.0 := Ranges [1.x, <1.5.0, 2.x, <1.5.0] and Join [and, or, and]
 type Constraint struct {
-	Original string
-	Ranges   []Range
-	Join     []JoinOp
+	Original   string
+	Ranges     []Range
+	Join       []JoinOp
+	Expression *Expression
+	// The least stable of the stability flags of a Composer constraint, e.g. beta for ^2.0@beta or ^2.0-beta1, empty if it has none
+	Stability string
 }
 
+// Returns the desugared expression tree of the constraint in constraint syntax
+//
+//	ex: ^1.2.3 || 2.x := >=1.2.3 <2.0.0-0 || >=2.0.0 <3.0.0-0
+//	ex: (1.x || 2.x) && <1.5.0 := (>=1.0.0 <2.0.0-0 || >=2.0.0 <3.0.0-0) && <1.5.0
+//
+// Use Canonical to get a normalized representation
 func (c *Constraint) String() string {
-	stringRep := ""
-	joinLength := len(c.Join)
-	for idx, r := range c.Ranges {
-		if idx < joinLength {
-			stringRep = stringRep + r.String() + string(c.Join[idx])
-		} else {
-			stringRep = stringRep + r.String()
-		}
-	}
-	return stringRep
+	return c.ExpressionTree().String()
 }
 
 // Parses a given node semver constraint string into a constraint object
+// If the constraint cannot be parsed, the returned error is a *ParseError
 func ParseConstraint(constraintString string) (Constraint, error) {
-	tokens, literals := LexConstraint(constraintString)
+	return ParseConstraintWithOptions(constraintString, ParseOptions{})
+}
 
-	// Check for illegal tokens
-	if slices.Contains(tokens, ILLEGAL) || slices.Contains(tokens, OPEN_PARENTHESIS) || slices.Contains(tokens, CLOSE_PARENTHESIS) {
-		indicies := []int{}
-		indicies = append(indicies, utils.GetAllIndicies(tokens, ILLEGAL)...)
-		indicies = append(indicies, utils.GetAllIndicies(tokens, OPEN_PARENTHESIS)...)
-		indicies = append(indicies, utils.GetAllIndicies(tokens, CLOSE_PARENTHESIS)...)
-		err := newIllegalTokenInConstraint(fmt.Sprintf("Found illegal token in the tokenized string.\n\tHere: %s", getConstraintErrorString(tokens, literals, indicies)))
-		log.Println(err)
+// Equivalent to ParseConstraint, but warnings and errors found while parsing are reported to the diagnostics sink of the options
+func ParseConstraintWithOptions(constraintString string, options ParseOptions) (Constraint, error) {
+	constraint, err := parseConstraint(constraintString, options)
+	if err != nil {
+		options.reportError(constraintString, err)
 		return Constraint{}, err
 	}
+	return constraint, nil
+}
+
+func parseConstraint(constraintString string, options ParseOptions) (Constraint, error) {
+	tokens, literals, offsets := []Token{}, []string{}, []int{}
+	if options.Ecosystem == version.COMPOSER_ECOSYSTEM {
+		tokens, literals, offsets = lexComposerConstraint(constraintString)
+	} else if options.Loose {
+		var rewrites []version.Rewrite
+		tokens, literals, offsets, rewrites = lexLooseConstraint(constraintString)
+		for _, rewrite := range rewrites {
+			options.report(Diagnostic{
+				Severity:   SEVERITY_INFO,
+				Code:       INFO_LOOSE_REWRITE,
+				Constraint: constraintString,
+				Offset:     rewrite.Offset,
+				Literal:    rewrite.Original,
+				Message:    fmt.Sprintf("%s: '%s' => '%s'", rewrite.Message, rewrite.Original, rewrite.Replacement),
+			})
+		}
+	} else {
+		tokens, literals, offsets = lexConstraint(constraintString)
+	}
+
+	// Check for illegal tokens
+	if slices.Contains(tokens, ILLEGAL) {
+		return Constraint{}, newParseError(ErrIllegalCharacterInConstraint, ERR_ILLEGAL_TOKEN, "Found illegal token", literals, offsets, slices.Index(tokens, ILLEGAL))
+	}
+
+	// Composer comparators are desugared into comparators of this grammar first, their stability flags are kept aside
+	stability := ""
+	var revisions []uint64
+	if options.Ecosystem == version.COMPOSER_ECOSYSTEM {
+		var err error
+		tokens, literals, offsets, stability, err = desugarComposerTokens(tokens, literals, offsets)
+		if err != nil {
+			return Constraint{}, err
+		}
+		literals, revisions = splitComposerRevisions(tokens, literals)
+	}
 
 	// Check if the constraint is correctly composed
-	err := validateConstraintComposition(tokens, literals)
+	if err := validateConstraintComposition(tokens, literals, offsets); err != nil {
+		return Constraint{}, err
+	}
+
+	// Each sub constraint is "desugared" into a simple range. e.g. >= 5.0.0 =< 6.0.0
+	// This is because all other operators: ~, ^, .x, Any, *, are simply syntactic sugar for a range
+	//
+	// Sub constraints are the leafs of the expression tree, grouped by parentheses and join operators
+	parser := expressionParser{source: constraintString, tokens: tokens, literals: literals, offsets: offsets, revisions: revisions, position: 1, options: options}
+	expression, err := parser.parseDisjunction()
 	if err != nil {
-		log.Println(err)
 		return Constraint{}, err
 	}
+	if parser.tokens[parser.position] != EOF {
+		return Constraint{}, newErrInvalidConstraint(ERR_UNEXPECTED_TOKEN, "Found unexpected token after the end of the constraint", literals, offsets, parser.position, JOIN_OPERATOR_CLASS, END_CLASS)
+	}
 
-	constraint := Constraint{
-		Original: constraintString,
+	// The normal form is bounded before it is expanded, see MaxNormalFormRanges
+	if ranges, _ := expression.normalFormSize(); ranges > MaxNormalFormRanges {
+		return Constraint{}, newParseError(ErrConstraintTooComplex, ERR_TOO_COMPLEX, fmt.Sprintf("Found constraint that expands into more than %d ranges", MaxNormalFormRanges), literals, offsets, 0)
 	}
 
-	// Each sub constraint is "desugared" into a simple range. e.g. >= 5.0.0 =< 6.0.0
-	// This is because all other operators: ~, ^, .x, Any, *, are simply syntactic sugar for a range
+	constraint := Constraint{
+		Original:   constraintString,
+		Ranges:     []Range{},
+		Join:       []JoinOp{},
+		Expression: &expression,
+		Stability:  stability,
+	}
 
-	currentTokenList := []Token{}
-	currentLiteralList := []string{}
-	for idx, token := range tokens {
-		literal := literals[idx]
-		if token == OR || token == AND {
-			subConstraint, err := parseSubConstraint(currentTokenList, currentLiteralList)
-			if err != nil {
-				return Constraint{}, err
-			}
-			if token == AND {
+	for setIdx, set := range expression.disjunctiveNormalForm() {
+		if setIdx > 0 {
+			constraint.Join = append(constraint.Join, DISJUNCTION)
+		}
+		for rangeIdx, r := range set {
+			if rangeIdx > 0 {
 				constraint.Join = append(constraint.Join, CONJUNCTON)
 			}
-			if token == OR {
-				constraint.Join = append(constraint.Join, DISJUNCTION)
-			}
-			constraint.Ranges = append(constraint.Ranges, subConstraint)
-			currentTokenList = []Token{}
-			currentLiteralList = []string{}
-		} else if token == EOF {
-			subConstraint, err := parseSubConstraint(currentTokenList, currentLiteralList)
-			if err != nil {
-				return Constraint{}, err
-			}
-			constraint.Ranges = append(constraint.Ranges, subConstraint)
-			currentTokenList = []Token{}
-			currentLiteralList = []string{}
-		} else {
-			if token != SOF {
-				currentTokenList = append(currentTokenList, token)
-				currentLiteralList = append(currentLiteralList, literal)
-			}
+			constraint.Ranges = append(constraint.Ranges, r)
 		}
 	}
 
@@ -124,21 +160,215 @@ func ParseConstraint(constraintString string) (Constraint, error) {
 }
 
 // ParseConstraintWithEcosystem parses a constraint string for specified ecosystem
-// For now, this wraps the existing ParseConstraint function
-// TODO: Add Composer-specific constraint handling (~, ^) in future
+// Composer constraints follow the grammar of Composer, any other ecosystem the grammar of nodejs, see ParseOptions.Ecosystem
 func ParseConstraintWithEcosystem(constraintString string, ecosystem string) (Constraint, error) {
-	// For now, use existing logic regardless of ecosystem
-	// Future enhancement: Add Composer-specific constraint parsing
-	return ParseConstraint(constraintString)
+	return ParseConstraintWithOptions(constraintString, ParseOptions{Ecosystem: ecosystem})
 }
 
-func validateConstraintComposition(tokens []Token, literals []string) error {
+// Parses a validated token list into an expression tree, by recursive descent
+//
+//	disjunction := conjunction { || conjunction }
+//	conjunction := operand { && operand }
+//	operand     := ( disjunction ) | sub constraint
+type expressionParser struct {
+	source   string
+	tokens   []Token
+	literals []string
+	offsets  []int
+	// The revisions of the versions of desugared Composer comparators by token, nil for other ecosystems, see splitComposerRevisions
+	revisions []uint64
+	position  int
+	options   ParseOptions
+}
+
+func (parser *expressionParser) parseDisjunction() (Expression, error) {
+	operands := []Expression{}
+	for {
+		operand, err := parser.parseConjunction()
+		if err != nil {
+			return Expression{}, err
+		}
+		operands = append(operands, operand)
+		if parser.tokens[parser.position] != OR {
+			return newJoinExpression(OR_EXPRESSION, operands), nil
+		}
+		parser.position++
+	}
+}
+
+func (parser *expressionParser) parseConjunction() (Expression, error) {
+	operands := []Expression{}
+	for {
+		operand, err := parser.parseOperand()
+		if err != nil {
+			return Expression{}, err
+		}
+		operands = append(operands, operand)
+		if parser.tokens[parser.position] != AND {
+			return newJoinExpression(AND_EXPRESSION, operands), nil
+		}
+		parser.position++
+	}
+}
+
+func (parser *expressionParser) parseOperand() (Expression, error) {
+	if parser.tokens[parser.position] == OPEN_PARENTHESIS {
+		openIdx := parser.position
+		parser.position++
+		group, err := parser.parseDisjunction()
+		if err != nil {
+			return Expression{}, err
+		}
+		if parser.tokens[parser.position] != CLOSE_PARENTHESIS {
+			return Expression{}, newErrInvalidConstraint(ERR_UNBALANCED_PARENTHESIS, "Found opening parenthesis without a matching closing parenthesis", parser.literals, parser.offsets, openIdx, CLOSE_PARENTHESIS_CLASS)
+		}
+		parser.position++
+		return group, nil
+	}
+
+	// Exclusions are comparators on their own, that are implicitly joined to the sub constraint they appear in
+	//   e.g. >= 1.0.0 != 1.3.2 < 2.0.0 := >= 1.0.0 < 2.0.0 && != 1.3.2
+	start := parser.position
+	subConstraintTokens := []Token{}
+	subConstraintLiterals := []string{}
+	subConstraintPositions := []int{}
+	exclusions := []Expression{}
+	for !slices.Contains([]Token{AND, OR, OPEN_PARENTHESIS, CLOSE_PARENTHESIS, EOF}, parser.tokens[parser.position]) {
+		if parser.tokens[parser.position] == NOT {
+			exclusion, err := parser.parseSubConstraint([]int{parser.position, parser.position + 1})
+			if err != nil {
+				return Expression{}, parser.newSubConstraintError(err, parser.position, parser.position+2)
+			}
+			exclusions = append(exclusions, NewRangeExpression(exclusion))
+			parser.position += 2
+			continue
+		}
+		subConstraintTokens = append(subConstraintTokens, parser.tokens[parser.position])
+		subConstraintLiterals = append(subConstraintLiterals, parser.literals[parser.position])
+		subConstraintPositions = append(subConstraintPositions, parser.position)
+		parser.position++
+	}
+
+	operands := []Expression{}
+	if len(subConstraintTokens) > 0 || len(exclusions) == 0 {
+		subConstraint, err := parser.parseSubConstraint(subConstraintPositions)
+		if err != nil {
+			return Expression{}, parser.newSubConstraintError(err, start, parser.position)
+		}
+		operands = append(operands, NewRangeExpression(subConstraint))
+		if isHyphenatedRange(subConstraintTokens) {
+			parser.warnPartialHyphenVersions(start)
+		}
+	}
+	return newJoinExpression(AND_EXPRESSION, append(operands, exclusions...)), nil
+}
+
+// Desugars the sub constraint of the tokens at the given positions
+// The versions of desugared Composer comparators keep their revision, e.g. >=1.2.3.4-0
+func (parser *expressionParser) parseSubConstraint(positions []int) (Range, error) {
+	tokens, literals, revisions := []Token{}, []string{}, []uint64{}
+	for _, position := range positions {
+		tokens = append(tokens, parser.tokens[position])
+		literals = append(literals, parser.literals[position])
+		if parser.revisions != nil && parser.tokens[position] == VERSION_EXPRESSION {
+			revisions = append(revisions, parser.revisions[position])
+		}
+	}
+
+	if devRange, ok := parseComposerDevRange(tokens, literals); ok && parser.options.Ecosystem == version.COMPOSER_ECOSYSTEM {
+		return withComposerRevisions(devRange, revisions), nil
+	}
+	subConstraint, err := parseSubConstraint(tokens, literals)
+	if err != nil {
+		return Range{}, err
+	}
+	return withComposerRevisions(subConstraint, revisions), nil
+}
+
+// Warns about partial versions in the hyphenated range starting at the given position, as their meaning differs per side
+//
+//	ex: 1.2 - 2.3 := >=1.2.0 <2.4.0-0
+func (parser *expressionParser) warnPartialHyphenVersions(start int) {
+	for _, idx := range []int{start, start + 2} {
+		if version.IsPartialVersion(parser.literals[idx]) {
+			parser.options.report(Diagnostic{
+				Severity:   SEVERITY_WARNING,
+				Code:       WARN_PARTIAL_HYPHEN_VERSION,
+				Constraint: parser.source,
+				Offset:     parser.offsets[idx],
+				Literal:    parser.literals[idx],
+				Message:    "partial version in hyphen range",
+			})
+		}
+	}
+}
+
+// Returns the error for a sub constraint between the positions start (inclusive) and end (exclusive) that could not be desugared
+// The literal of the error spans the whole sub constraint
+func (parser *expressionParser) newSubConstraintError(err error, start int, end int) *ParseError {
+	parseError := &ParseError{
+		Offset:  parser.offsets[start],
+		Literal: strings.TrimSpace(parser.source[parser.offsets[start]:parser.offsets[end]]),
+		Code:    ERR_INVALID_VERSION,
+		Message: "Found invalid version in sub constraint",
+		Err:     ErrInvalidVersion,
+		Cause:   err,
+	}
+	if errors.Is(err, errUnknownSubConstraint) || errors.Is(err, errInvalidRange) {
+		parseError.Code = ERR_INVALID_RANGE
+		parseError.Message = "Found invalid sub constraint"
+		parseError.Err = ErrInvalidConstraint
+	}
+	return parseError
+}
+
+func validateConstraintComposition(tokens []Token, literals []string, offsets []int) error {
 
 	joinOperatorsProcessed := []Token{}
 	tokensProcessed := []Token{}
 	previousToken := SOF
+	groupDepth := 0
 	for idx, token := range tokens {
 
+		// A group must be opened before it is closed, and closed before the end of the constraint
+		//   e.g. >= 1.0.0 ) || ( 2.x
+		if token == OPEN_PARENTHESIS {
+			groupDepth++
+		}
+		if token == CLOSE_PARENTHESIS {
+			if groupDepth == 0 {
+				return newErrInvalidConstraint(ERR_UNBALANCED_PARENTHESIS, "Found closing parenthesis without a matching opening parenthesis", literals, offsets, idx)
+			}
+			groupDepth--
+		}
+		if token == EOF && groupDepth > 0 {
+			return newErrInvalidConstraint(ERR_UNBALANCED_PARENTHESIS, "Found opening parenthesis without a matching closing parenthesis", literals, offsets, idx, CLOSE_PARENTHESIS_CLASS)
+		}
+
+		// A group cannot be empty
+		//   e.g. 1.x || ()
+		if token == CLOSE_PARENTHESIS && previousToken == OPEN_PARENTHESIS {
+			return newErrInvalidConstraint(ERR_EMPTY_GROUP, "Found empty group of parentheses", literals, offsets, idx, VERSION_CLASS, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, OPEN_PARENTHESIS_CLASS)
+		}
+
+		// A group cannot start or end with a join token
+		//   e.g. ( || 1.x ) or ( 1.x && )
+		if (IsJoinToken(token) && previousToken == OPEN_PARENTHESIS) || (token == CLOSE_PARENTHESIS && IsJoinToken(previousToken)) {
+			return newErrInvalidConstraint(ERR_MISPLACED_JOIN, "Found join operator (&&, ||) at the start or the end of a group", literals, offsets, idx, VERSION_CLASS, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, OPEN_PARENTHESIS_CLASS)
+		}
+
+		// A group must be joined to its surroundings by a join token
+		//   e.g. 1.x ( 2.x ) or ( 1.x ) 2.x
+		if (token == OPEN_PARENTHESIS && (previousToken == VERSION_EXPRESSION || previousToken == CLOSE_PARENTHESIS)) || (token == VERSION_EXPRESSION && previousToken == CLOSE_PARENTHESIS) {
+			return newErrInvalidConstraint(ERR_MISSING_JOIN, "Found group that is not joined by a join operator (&&, ||)", literals, offsets, idx, JOIN_OPERATOR_CLASS)
+		}
+
+		// A range or equality operator applies to a version, not to a group
+		//   e.g. >= ( 1.0.0 )
+		if (token == OPEN_PARENTHESIS || token == CLOSE_PARENTHESIS) && (IsRangeToken(previousToken) || IsEqualityToken(previousToken)) {
+			return newErrInvalidConstraint(ERR_MISSING_VERSION, "Found range operator (>=, >, <, <=, -, ~, ^) or equality operator (=, !=) that is not followed by a version", literals, offsets, idx, VERSION_CLASS)
+		}
+
 		// A constraint that is empty is not valid
 		// if token == EOF && previousToken == SOF {
 		// 	return EmptyConstraint
@@ -147,30 +377,30 @@ func validateConstraintComposition(tokens []Token, literals []string) error {
 		// A version constraint cannot start with a join token
 		//   e.g. && 5.0.0 || 2.0.0
 		if IsJoinToken(token) && previousToken == SOF {
-			return newErrInvalidConstraint(fmt.Sprintf("Found join operator (&&, ||) at the start of the constraint.\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+			return newErrInvalidConstraint(ERR_MISPLACED_JOIN, "Found join operator (&&, ||) at the start of the constraint", literals, offsets, idx, VERSION_CLASS, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, OPEN_PARENTHESIS_CLASS)
 		}
 
 		// A version constraint cannot end with a join token
 		//   e.g. 1.x || >= 5.0.0 &&
 		if token == EOF && IsJoinToken(previousToken) {
-			return newErrInvalidConstraint(fmt.Sprintf("Found join operator (&&, ||) at the end of the constraint.\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+			return newErrInvalidConstraint(ERR_MISPLACED_JOIN, "Found join operator (&&, ||) at the end of the constraint", literals, offsets, idx, VERSION_CLASS, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, OPEN_PARENTHESIS_CLASS)
 		}
 
 		// A constraint cannot contain two subsequent range tokens or equality tokens
 		if (IsRangeToken(token) || IsEqualityToken(token)) && (IsRangeToken(previousToken) || IsEqualityToken(previousToken)) {
-			return newErrInvalidConstraint(fmt.Sprintf("Found two subsequent range operators (>=, >, <, <=, -, ~, ^) or equality operators (!,=).\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+			return newErrInvalidConstraint(ERR_CONSECUTIVE_OPERATORS, "Found two subsequent range operators (>=, >, <, <=, -, ~, ^) or equality operators (=, !=)", literals, offsets, idx, VERSION_CLASS)
 		}
 
 		// A constraint cannot contain two subsequent join tokens
 		if IsJoinToken(token) && IsJoinToken(previousToken) {
-			return newErrInvalidConstraint(fmt.Sprintf("Found two subsequent join operators (&&, ||).\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+			return newErrInvalidConstraint(ERR_CONSECUTIVE_OPERATORS, "Found two subsequent join operators (&&, ||)", literals, offsets, idx, VERSION_CLASS, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, OPEN_PARENTHESIS_CLASS)
 		}
 
 		// A constraint cannot contain two subsequent static version expressions
 		//
 		// where static version means a simple version that does not have .x .* or ANY
 		if (token == VERSION_EXPRESSION && version.IsStaticVersion(literals[idx])) && (previousToken == VERSION_EXPRESSION && version.IsStaticVersion(literals[idx-1])) {
-			return newErrInvalidConstraint(fmt.Sprintf("Found two subsequent static version expression that are not joined by join operator (&& , ||), or a range operator (>=, >, <, <=, -, ~, ^).\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+			return newErrInvalidConstraint(ERR_MISSING_JOIN, "Found two subsequent static version expression that are not joined by join operator (&& , ||), or a range operator (>=, >, <, <=, -, ~, ^)", literals, offsets, idx, JOIN_OPERATOR_CLASS, RANGE_OPERATOR_CLASS)
 		}
 
 		// We cannot have a version expression without a preceeding operator token (join, equality or range)
@@ -194,7 +424,7 @@ func validateConstraintComposition(tokens []Token, literals []string) error {
 			}
 
 			if previousToken != HYPHEN && nextToken != HYPHEN && version.IsStaticVersion(literals[idx]) {
-				return newErrInvalidConstraint(fmt.Sprintf("Found a version expression without a preceeding (>=, >, <, <=, -, ~, ^) range, equality (=, !) or join operator (&&, ||).\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+				return newErrInvalidConstraint(ERR_MISSING_OPERATOR, "Found a version expression without a preceeding (>=, >, <, <=, -, ~, ^) range, equality (=, !=) or join operator (&&, ||)", literals, offsets, idx, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, JOIN_OPERATOR_CLASS)
 			}
 		}
 
@@ -202,33 +432,30 @@ func validateConstraintComposition(tokens []Token, literals []string) error {
 		//  e.g. 4.0.0 || 5.0.0
 		//  e.g. >= 2.0.0 && =< 4.0.0
 		//  e.g. >= 2.0.0 =< 4.0.0
+		//  e.g. 2.x != 2.1.0
 		if len(tokensProcessed) >= 3 && token == VERSION_EXPRESSION && tokensProcessed[idx-2] == VERSION_EXPRESSION {
-			if (!IsRangeToken(previousToken) && !IsJoinToken(previousToken)) && !IsRangeToken(tokensProcessed[idx-3]) && !IsJoinToken(tokensProcessed[idx-3]) {
-				return newErrInvalidConstraint(fmt.Sprintf("Found two subsequent version expressions that are not joined by join (&&, ||)) or range operator (>=, >, <, <=, -, ~, ^).\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+			if (!IsRangeToken(previousToken) && !IsJoinToken(previousToken) && previousToken != NOT) && !IsRangeToken(tokensProcessed[idx-3]) && !IsJoinToken(tokensProcessed[idx-3]) {
+				return newErrInvalidConstraint(ERR_MISSING_JOIN, "Found two subsequent version expressions that are not joined by join (&&, ||)) or range operator (>=, >, <, <=, -, ~, ^)", literals, offsets, idx, JOIN_OPERATOR_CLASS, RANGE_OPERATOR_CLASS)
 			}
 		}
 
 		// Two (or more) subsequent "static" version expressions cannot be joined by a conjunction
 		// if the operator of both version expressions is an equlity operator
 		//  e.g. = 4.0.0 && = 5.0.0 is not allowed
-		//  e.g. ! 4.0.0 && = 5.0.0 is not allowed
 		//  but  = 4.0.0 || = 5.0.0 is allowed
+		//  and  != 4.0.0 && != 5.0.0 is allowed, as exclusions do not contradict each other
 		//
 		// where static version means a simple version that does not have .x .* or ANY
-		if token == VERSION_EXPRESSION && (previousToken == EQ || previousToken == NOT) {
+		if token == VERSION_EXPRESSION && previousToken == EQ {
 			idxEq := slices.Index(tokensProcessed[0:len(tokensProcessed)-1], EQ)
 
-			if idxEq == -1 {
-				idxEq = slices.Index(tokensProcessed[0:len(tokensProcessed)-1], NOT)
-			}
-
 			if idxEq != -1 {
 				versionLiteral := literals[idx]
 				versionLiteralBefore := literals[idxEq+1]
 				if version.IsStaticVersion(versionLiteral) && version.IsStaticVersion(versionLiteralBefore) {
 					if len(joinOperatorsProcessed) > 0 {
 						if joinOperatorsProcessed[len(joinOperatorsProcessed)-1] == AND {
-							return newErrInvalidConstraint(fmt.Sprintf("Found two static versions joined by &&, which is a logical impossiblity.\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idxEq + 1, idx})))
+							return newErrInvalidConstraint(ERR_CONTRADICTING_VERSIONS, "Found two static versions joined by &&, which is a logical impossiblity", literals, offsets, idx)
 						}
 					}
 				}
@@ -254,22 +481,34 @@ func validateConstraintComposition(tokens []Token, literals []string) error {
 
 		// }
 
+		// An exclusion applies to a single static version
+		//   e.g. != 1.x is not allowed, since it would exclude a range
+		if token == NOT {
+			nextToken := EOF
+			if idx+1 <= len(tokens)-1 {
+				nextToken = tokens[idx+1]
+			}
+			if nextToken != VERSION_EXPRESSION || !version.IsStaticVersion(literals[idx+1]) {
+				return newErrInvalidConstraint(ERR_INVALID_EXCLUSION, "Found exclusion operator (!=) that is not followed by a static version", literals, offsets, idx+1, STATIC_VERSION_CLASS)
+			}
+		}
+
 		// A valid hyphenated range must consist of two static or partial versions, a start version and an end version joined in the middle by a hyphen
 		if token == HYPHEN {
 
 			// Hyphen found but no static or partial start version
 			if previousToken != VERSION_EXPRESSION {
-				return newErrInvalidConstraint(fmt.Sprintf("Found hyphenated range that is not prefixed with a version.\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+				return newErrInvalidConstraint(ERR_INVALID_HYPHEN_RANGE, "Found hyphenated range that is not prefixed with a version", literals, offsets, idx, VERSION_CLASS)
 			}
 
 			// Hyphen and start version found, but start version is not a static or partial version
 			if !version.IsPartialVersion(literals[idx-1]) && !version.IsStaticVersion(literals[idx-1]) {
-				return newErrInvalidConstraint(fmt.Sprintf("Found hyphenated range that is not prefixed with a static or partial version.\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+				return newErrInvalidConstraint(ERR_INVALID_HYPHEN_RANGE, "Found hyphenated range that is not prefixed with a static or partial version", literals, offsets, idx-1, STATIC_VERSION_CLASS)
 			}
 
-			// Hyphen and start version found, but start version is not a static or partial version
-			if idx > 3 && (IsRangeToken(tokens[idx-2]) || IsEqualityToken(tokens[idx-2])) {
-				return newErrInvalidConstraint(fmt.Sprintf("Found out of place range (>=, >, <, <=, -, ~, ^) or equality token (=, !) in hyphenated range.\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx, idx - 4})))
+			// Hyphen and start version found, but the start version is preceded by a range or equality operator
+			if idx >= 2 && (IsRangeToken(tokens[idx-2]) || IsEqualityToken(tokens[idx-2])) {
+				return newErrInvalidConstraint(ERR_INVALID_HYPHEN_RANGE, "Found out of place range (>=, >, <, <=, -, ~, ^) or equality token (=, !=) in hyphenated range", literals, offsets, idx-2, STATIC_VERSION_CLASS)
 			}
 
 			// Hyphen found but no end version
@@ -279,13 +518,13 @@ func validateConstraintComposition(tokens []Token, literals []string) error {
 			}
 
 			if nextToken != VERSION_EXPRESSION {
-				return newErrInvalidConstraint(fmt.Sprintf("Found hyphenated range that is not complete. End version is missing.\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+				return newErrInvalidConstraint(ERR_INVALID_HYPHEN_RANGE, "Found hyphenated range that is not complete. End version is missing", literals, offsets, idx+1, STATIC_VERSION_CLASS)
 			}
 
 			// Hyphen, start and end version found, but end version is not a static or partial version
 			if nextToken == VERSION_EXPRESSION {
 				if !version.IsPartialVersion(literals[idx+1]) && !version.IsStaticVersion(literals[idx+1]) {
-					return newErrInvalidConstraint(fmt.Sprintf("Found hyphenated range that is not suffixed with a static or partial version. End version is not static.\n\tHere: %s", getConstraintErrorString(tokens, literals, []int{idx})))
+					return newErrInvalidConstraint(ERR_INVALID_HYPHEN_RANGE, "Found hyphenated range that is not suffixed with a static or partial version. End version is not static", literals, offsets, idx+1, STATIC_VERSION_CLASS)
 				}
 			}
 
@@ -326,12 +565,16 @@ func parseSubConstraint(tokens []Token, literals []string) (Range, error) {
 	case isPartialRange(tokens, literals):
 		semverRange, err := parseXRange(literals)
 		return semverRange, err
+	case isEqualityXRange(tokens, literals):
+		// An equality operator does not change the meaning of a partial version, e.g. =1.2 := 1.2.x
+		semverRange, err := parseXRange(literals[1:])
+		return semverRange, err
 	case isXRange(tokens, literals):
 		// https://github.com/npm/codeclarity.io/node-semver#x-ranges-12x-1x-12-
 		semverRange, err := parseXRange(literals)
 		return semverRange, err
 	default:
-		return Range{}, errors.New("unknown sub constraint type")
+		return Range{}, errUnknownSubConstraint
 	}
 
 }
@@ -403,6 +646,10 @@ func isStaticRange(tokenList []Token, literalList []string) bool {
 	return len(tokenList) == 2 && IsEqualityToken(tokenList[0]) && tokenList[1] == VERSION_EXPRESSION && version.IsStaticVersion(literalList[1])
 }
 
+func isEqualityXRange(tokenList []Token, literalList []string) bool {
+	return len(tokenList) == 2 && tokenList[0] == EQ && tokenList[1] == VERSION_EXPRESSION && (version.IsPartialVersion(literalList[1]) || version.IsWildCardVersion(literalList[1]))
+}
+
 func isPartialRange(tokenList []Token, literalList []string) bool {
 	return len(tokenList) == 1 && tokenList[0] == VERSION_EXPRESSION && version.IsPartialVersion(literalList[0])
 }
@@ -461,23 +708,19 @@ func parseHyphen(literalList []string) (Range, error) {
 		parsedRange.EndOp = LT
 
 		if strings.Count(partialEndVersion, ".") == 0 {
-			parsed, err := strconv.Atoi(partialEndVersion)
+			major, err := incrementVersionPart(literalList[2], version.MAJOR_PART, partialEndVersion)
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
-			major := int(parsed)
-			major++
 			coercedPartialEndRange = fmt.Sprintf("%d.0.0", major)
 		}
 
 		if strings.Count(partialEndVersion, ".") == 1 {
-			parsed, err := strconv.Atoi(strings.Split(partialEndVersion, ".")[1])
+			minor, err := incrementVersionPart(literalList[2], version.MINOR_PART, strings.Split(partialEndVersion, ".")[1])
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
 			major := strings.Split(partialEndVersion, ".")[0]
-			minor := int(parsed)
-			minor++
 			coercedPartialEndRange = fmt.Sprintf("%s.%d.0", major, minor)
 		}
 
@@ -499,23 +742,19 @@ func parseHyphen(literalList []string) (Range, error) {
 		}
 
 		if strings.Count(partialEndVersion, ".") == 0 {
-			parsed, err := strconv.Atoi(partialEndVersion)
+			major, err := incrementVersionPart(literalList[2], version.MAJOR_PART, partialEndVersion)
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
-			major := int(parsed)
-			major++
 			coercedPartialEndRange = fmt.Sprintf("%d.0.0", major)
 		}
 
 		if strings.Count(partialEndVersion, ".") == 1 {
-			parsed, err := strconv.Atoi(strings.Split(partialEndVersion, ".")[1])
+			minor, err := incrementVersionPart(literalList[2], version.MINOR_PART, strings.Split(partialEndVersion, ".")[1])
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
 			major := strings.Split(partialEndVersion, ".")[0]
-			minor := int(parsed)
-			minor++
 			coercedPartialEndRange = fmt.Sprintf("%s.%d.0", major, minor)
 		}
 
@@ -533,7 +772,7 @@ func parseHyphen(literalList []string) (Range, error) {
 	if err != nil {
 		return Range{}, err
 	}
-	parsedRange.EndVersion = semver
+	parsedRange.EndVersion = excludePreReleasesOf(parsedRange.EndOp, semver)
 
 	return parsedRange, nil
 }
@@ -596,12 +835,10 @@ func parseTildeRange(literalList []string) (Range, error) {
 	// ~1.2.3 	:= >=1.2.3 <1.(2+1).0 	:= >=1.2.3 <1.3.0-0
 	// ~0.2.3 	:= >=0.2.3 <0.(2+1).0 	:= >=0.2.3 <0.3.0-0
 	if strings.Count(versionString, ".") == 2 {
-		parsed, err := strconv.Atoi(minorString)
+		minor, err := incrementVersionPart(literalList[1], version.MINOR_PART, minorString)
 		if err != nil {
-			return Range{}, ErrInvalidVersion
+			return Range{}, err
 		}
-		minor := int(parsed)
-		minor++
 		startVersionString = getVersionStringFromMetaPreReleaseParts(versionString, metaDataPart, preReleasePart)
 		endVersionString = fmt.Sprintf("%s.%d.0", majorString, minor)
 	}
@@ -609,12 +846,10 @@ func parseTildeRange(literalList []string) (Range, error) {
 	// ~1.2 	:= >=1.2.0 <1.(2+1).0 	:= >=1.2.0 <1.3.0-0
 	// ~0.2 	:= >=0.2.0 <0.(2+1).0 	:= >=0.2.0 <0.3.0-0
 	if strings.Count(versionString, ".") == 1 {
-		parsed, err := strconv.Atoi(minorString)
+		minor, err := incrementVersionPart(literalList[1], version.MINOR_PART, minorString)
 		if err != nil {
-			return Range{}, ErrInvalidVersion
+			return Range{}, err
 		}
-		minor := int(parsed)
-		minor++
 		startVersionString = getVersionStringFromMetaPreReleaseParts(fmt.Sprintf("%s.0", versionString), metaDataPart, preReleasePart)
 		endVersionString = fmt.Sprintf("%s.%d.0", majorString, minor)
 	}
@@ -622,12 +857,10 @@ func parseTildeRange(literalList []string) (Range, error) {
 	// ~1 	:= >=1.0.0 <(1+1).0.0 	:= >=1.0.0 <2.0.0-0
 	// ~0 	:= >=0.0.0 <(0+1).0.0 	:= >=0.0.0 <1.0.0-0
 	if strings.Count(versionString, ".") == 0 {
-		parsed, err := strconv.Atoi(versionString)
+		major, err := incrementVersionPart(literalList[1], version.MAJOR_PART, versionString)
 		if err != nil {
-			return Range{}, ErrInvalidVersion
+			return Range{}, err
 		}
-		major := int(parsed)
-		major++
 		startVersionString = getVersionStringFromMetaPreReleaseParts(fmt.Sprintf("%s.0.0", versionString), metaDataPart, preReleasePart)
 		endVersionString = fmt.Sprintf("%d.0.0", major)
 	}
@@ -641,20 +874,20 @@ func parseTildeRange(literalList []string) (Range, error) {
 	if err != nil {
 		return Range{}, err
 	}
-	parsedRange.EndVersion = semver
+	parsedRange.EndVersion = excludePreReleasesOf(parsedRange.EndOp, semver)
 
 	return parsedRange, nil
 }
 
 // ^*      -->  (any)
-// ^1.2.3  -->  >=1.2.3 <2.0.0
-// ^1.2    -->  >=1.2.0 <2.0.0
-// ^1      -->  >=1.0.0 <2.0.0
-// ^0.2.3  -->  >=0.2.3 <0.3.0
-// ^0.2    -->  >=0.2.0 <0.3.0
-// ^0.0.3  -->  >=0.0.3 <0.0.4
-// ^0.0    -->  >=0.0.0 <0.1.0
-// ^0      -->  >=0.0.0 <1.0.0
+// ^1.2.3  -->  >=1.2.3 <2.0.0-0
+// ^1.2    -->  >=1.2.0 <2.0.0-0
+// ^1      -->  >=1.0.0 <2.0.0-0
+// ^0.2.3  -->  >=0.2.3 <0.3.0-0
+// ^0.2    -->  >=0.2.0 <0.3.0-0
+// ^0.0.3  -->  >=0.0.3 <0.0.4-0
+// ^0.0    -->  >=0.0.0 <0.1.0-0
+// ^0      -->  >=0.0.0 <1.0.0-0
 func parseCaretRange(literalList []string) (Range, error) {
 
 	// https://github.com/npm/codeclarity.io/node-semver#caret-ranges-123-025-004
@@ -718,12 +951,10 @@ func parseCaretRange(literalList []string) (Range, error) {
 	//   e.g. ^1.x 	:= 	>=1.0.0 <2.0.0-0
 	//   e.g. ^0.x 	:= 	>=0.0.0 <1.0.0-0
 	if minorString == "x" && patchString == "x" {
-		parsed, err := strconv.Atoi(majorString)
+		major, err := incrementVersionPart(literalList[1], version.MAJOR_PART, majorString)
 		if err != nil {
-			return Range{}, ErrInvalidVersion
+			return Range{}, err
 		}
-		major := int(parsed)
-		major++
 
 		startVersionString = getVersionStringFromParts(majorString, "0", "0", metaDataPart, preReleasePart)
 		endVersionString = getVersionStringFromParts(fmt.Sprintf("%d", major), "0", "0", "", "")
@@ -738,28 +969,22 @@ func parseCaretRange(literalList []string) (Range, error) {
 		//   e.g. ^0.0.x 	:= 	>=0.0.0 <0.1.0-0
 		//   e.g. ^0.0 		:= 	>=0.0.0 <0.1.0-0
 		if majorString != "0" {
-			parsed, err := strconv.Atoi(majorString)
+			major, err := incrementVersionPart(literalList[1], version.MAJOR_PART, majorString)
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
-			major := int(parsed)
-			major++
 			endVersionString = getVersionStringFromParts(fmt.Sprintf("%d", major), "0", "0", "", "")
 		} else if minorString != "0" {
-			parsed, err := strconv.Atoi(minorString)
+			minor, err := incrementVersionPart(literalList[1], version.MINOR_PART, minorString)
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
-			minor := int(parsed)
-			minor++
 			endVersionString = getVersionStringFromParts(majorString, fmt.Sprintf("%d", minor), "0", "", "")
 		} else {
-			parsed, err := strconv.Atoi(minorString)
+			minor, err := incrementVersionPart(literalList[1], version.MINOR_PART, minorString)
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
-			minor := int(parsed)
-			minor++
 			endVersionString = getVersionStringFromParts(majorString, fmt.Sprintf("%d", minor), "0", "", "")
 		}
 	} else {
@@ -770,28 +995,22 @@ func parseCaretRange(literalList []string) (Range, error) {
 		// ^0.2.3 	:= 	>=0.2.3 <0.3.0-0
 		// ^0.0.3 	:= 	>=0.0.3 <0.0.4-0
 		if majorString != "0" {
-			parsed, err := strconv.Atoi(majorString)
+			major, err := incrementVersionPart(literalList[1], version.MAJOR_PART, majorString)
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
-			major := int(parsed)
-			major++
 			endVersionString = getVersionStringFromParts(fmt.Sprintf("%d", major), "0", "0", "", "")
 		} else if minorString != "0" {
-			parsed, err := strconv.Atoi(minorString)
+			minor, err := incrementVersionPart(literalList[1], version.MINOR_PART, minorString)
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
-			minor := int(parsed)
-			minor++
 			endVersionString = getVersionStringFromParts(majorString, fmt.Sprintf("%d", minor), "0", "", "")
 		} else {
-			parsed, err := strconv.Atoi(patchString)
+			patch, err := incrementVersionPart(literalList[1], version.PATCH_PART, patchString)
 			if err != nil {
-				return Range{}, ErrInvalidVersion
+				return Range{}, err
 			}
-			patch := int(parsed)
-			patch++
 			endVersionString = getVersionStringFromParts(majorString, minorString, fmt.Sprintf("%d", patch), "", "")
 		}
 	}
@@ -805,7 +1024,7 @@ func parseCaretRange(literalList []string) (Range, error) {
 	if err != nil {
 		return Range{}, err
 	}
-	parsedRange.EndVersion = semver
+	parsedRange.EndVersion = excludePreReleasesOf(parsedRange.EndOp, semver)
 
 	return parsedRange, nil
 
@@ -851,7 +1070,7 @@ func parsePartsOrZero(versionString string) string {
 	}
 
 	if metaDataPart != "" && preReleasePart != "" {
-		return fmt.Sprintf("%s.%s.%s+%s-%s", majorString, minorString, patchString, metaDataPart, preReleasePart)
+		return fmt.Sprintf("%s.%s.%s-%s+%s", majorString, minorString, patchString, preReleasePart, metaDataPart)
 	} else if metaDataPart != "" {
 		return fmt.Sprintf("%s.%s.%s+%s", majorString, minorString, patchString, metaDataPart)
 	} else if preReleasePart != "" {
@@ -902,7 +1121,7 @@ func parseRange(tokenList []Token, literalList []string) (Range, error) {
 		return parsedRange, nil
 	}
 
-	return Range{}, errors.New("invalid range")
+	return Range{}, errInvalidRange
 
 }
 
@@ -957,21 +1176,17 @@ func parseXRange(literalList []string) (Range, error) {
 
 	if minorString == "" || minorString == "x" || minorString == "*" {
 		startVersionString = fmt.Sprintf("%s.0.0", majorString)
-		parsed, err := strconv.Atoi(majorString)
+		major, err := incrementVersionPart(literalList[0], version.MAJOR_PART, majorString)
 		if err != nil {
-			return Range{}, ErrInvalidVersion
+			return Range{}, err
 		}
-		major := int(parsed)
-		major++
 		endVersionString = fmt.Sprintf("%d.0.0", major)
 	} else if patchString == "" || patchString == "x" || patchString == "*" {
 		startVersionString = fmt.Sprintf("%s.%s.0", majorString, minorString)
-		parsed, err := strconv.Atoi(minorString)
+		minor, err := incrementVersionPart(literalList[0], version.MINOR_PART, minorString)
 		if err != nil {
-			return Range{}, ErrInvalidVersion
+			return Range{}, err
 		}
-		minor := int(parsed)
-		minor++
 		endVersionString = fmt.Sprintf("%s.%d.0", majorString, minor)
 	}
 
@@ -987,41 +1202,75 @@ func parseXRange(literalList []string) (Range, error) {
 			return Range{}, err
 		}
 		parsedRange.EndOp = LT
-		parsedRange.EndVersion = semver
+		parsedRange.EndVersion = excludePreReleasesOf(parsedRange.EndOp, semver)
 	}
 
 	return parsedRange, nil
 
 }
 
-func getConstraintErrorString(tokens []Token, literals []string, errorPositions []int) string {
-	formattedStrings := []string{}
-	for idx, token := range tokens {
-		if !slices.Contains(errorPositions, idx) {
-			formattedStrings = append(formattedStrings, fmt.Sprintf("`%s<'%s'>`", token, literals[idx]))
-		} else {
-			formattedStrings = append(formattedStrings, fmt.Sprintf("===> `%s<'%s'>` <===", token, literals[idx]))
-		}
+// Returns the version part incremented by one, for the exclusive end version of a desugared range
+// The highest possible part has no successor, so it is rejected with ErrVersionPartOverflow rather than wrapped around
+//
+//	ex: ^1.2.3 := major 2
+//	ex: ^18446744073709551615.0.0 := ErrVersionPartOverflow
+func incrementVersionPart(versionLiteral string, partName version.VersionPart, part string) (uint64, error) {
+	parsed, err := strconv.ParseUint(part, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidVersion
 	}
-	return fmt.Sprintf("%s\n\tIn: %s", strings.Join(formattedStrings, " • "), strings.Join(literals, ""))
-}
-
-func newErrInvalidConstraint(message string) error {
-	return fmt.Errorf("invalid Version Constraint: %s", message)
+	if parsed == math.MaxUint64 {
+		return 0, &version.InvalidVersionError{Version: versionLiteral, Part: partName, Identifier: part, Reason: version.ErrVersionPartOverflow}
+	}
+	return parsed + 1, nil
 }
 
-func newIllegalTokenInConstraint(message string) error {
-	return fmt.Errorf("invalid Version Constraint: %s", message)
+// Desugared ranges exclude the prereleases of their exclusive end version, as per the nodesemver spec
+//
+//	e.g. ^1.2.3 	:= 	>=1.2.3 <2.0.0-0
+//
+// Thus 2.0.0-beta.1 does not satisfy ^1.2.3, even if prereleases are included
+func excludePreReleasesOf(endOp Token, endVersion version.Semver) version.Semver {
+	if endOp == LT && endVersion.PreReleaseTag == "" {
+		endVersion.PreReleaseTag = "0"
+		endVersion.Raw = ""
+	}
+	return endVersion
 }
 
+// Returns the range in constraint syntax, e.g. >=1.0.0 <2.0.0-0
 func (parsedRange Range) String() string {
-	return fmt.Sprintf("%s %s %s %s", parsedRange.StartOp.toString(), parsedRange.StartVersion.String(), parsedRange.EndOp.toString(), parsedRange.EndVersion.String())
+	// A dev branch is written by its name, e.g. =dev-main
+	if parsedRange.StartVersion.DevBranch != "" {
+		return parsedRange.StartOp.toString() + "dev-" + parsedRange.StartVersion.DevBranch
+	}
+	if parsedRange.EndOp == "" {
+		return parsedRange.StartOp.toString() + rangeVersionString(parsedRange.StartVersion)
+	}
+	return fmt.Sprintf("%s%s %s%s", parsedRange.StartOp.toString(), rangeVersionString(parsedRange.StartVersion), parsedRange.EndOp.toString(), rangeVersionString(parsedRange.EndVersion))
+}
 
+// Returns the version of a range in constraint syntax, where the revision of a Composer version is kept, e.g. 1.2.3.4-0
+func rangeVersionString(v version.Semver) string {
+	formatted := v.String()
+	if v.Revision != 0 {
+		release := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+		formatted = fmt.Sprintf("%s.%d%s", release, v.Revision, strings.TrimPrefix(formatted, release))
+	}
+	// An exact Composer dev version keeps its modifier, e.g. =1.0.9999999.9999999-dev
+	if v.IsDev {
+		release, metaData, hasMetaData := strings.Cut(formatted, "+")
+		formatted = release + "-dev"
+		if hasMetaData {
+			formatted += "+" + metaData
+		}
+	}
+	return formatted
 }
 
 func getVersionStringFromParts(majorString string, minorString string, patchString string, metaDataPart string, preReleasePart string) string {
 	if metaDataPart != "" && preReleasePart != "" {
-		return fmt.Sprintf("%s.%s.%s+%s-%s", majorString, minorString, patchString, metaDataPart, preReleasePart)
+		return fmt.Sprintf("%s.%s.%s-%s+%s", majorString, minorString, patchString, preReleasePart, metaDataPart)
 	} else if metaDataPart != "" {
 		return fmt.Sprintf("%s.%s.%s+%s", majorString, minorString, patchString, metaDataPart)
 	} else if preReleasePart != "" {
@@ -1033,7 +1282,7 @@ func getVersionStringFromParts(majorString string, minorString string, patchStri
 
 func getVersionStringFromMetaPreReleaseParts(version string, metaDataPart string, preReleasePart string) string {
 	if metaDataPart != "" && preReleasePart != "" {
-		return fmt.Sprintf("%s+%s-%s", version, metaDataPart, preReleasePart)
+		return fmt.Sprintf("%s-%s+%s", version, preReleasePart, metaDataPart)
 	} else if metaDataPart != "" {
 		return fmt.Sprintf("%s+%s", version, metaDataPart)
 	} else if preReleasePart != "" {
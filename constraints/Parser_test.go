@@ -1,7 +1,9 @@
 package constraints
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/CodeClarityCE/utility-node-semver/versions"
@@ -117,7 +119,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 3},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -129,7 +131,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -141,7 +143,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -153,7 +155,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -165,7 +167,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -177,7 +179,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 2, Patch: 3},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -189,7 +191,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 2, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -201,7 +203,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 2, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -213,7 +215,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 3},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -225,7 +227,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -237,7 +239,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -249,7 +251,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -261,7 +263,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -273,7 +275,7 @@ func TestTildeRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "beta.2"},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -346,7 +348,7 @@ func TestHyphenatedRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 3},
-						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 4, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 4, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -358,7 +360,7 @@ func TestHyphenatedRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 3},
-						EndOp: LT, EndVersion: versions.Semver{Major: 3, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 3, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -382,7 +384,19 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 3},
-						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"},
+					},
+				},
+				Join: []JoinOp{},
+			},
+		},
+		{
+			ConstraintString: "^20231015123456.1.2",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{
+						StartOp: GE, StartVersion: versions.Semver{Major: 20231015123456, Minor: 1, Patch: 2},
+						EndOp: LT, EndVersion: versions.Semver{Major: 20231015123457, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -394,7 +408,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 2, Patch: 3},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -406,7 +420,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 3},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 0, Patch: 4},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 0, Patch: 4, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -418,7 +432,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -430,7 +444,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -442,7 +456,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 1, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -454,7 +468,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -466,7 +480,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -478,7 +492,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -490,7 +504,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "beta.2"},
-						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -502,7 +516,7 @@ func TestCaretRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 0, Minor: 0, Patch: 3, PreReleaseTag: "beta"},
-						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 0, Patch: 4},
+						EndOp: LT, EndVersion: versions.Semver{Major: 0, Minor: 0, Patch: 4, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -521,6 +535,19 @@ func TestXRangeParsing(t *testing.T) {
 	fmt.Printf("\n%s Testing X range parsing %s\n", "----------------", "----------------")
 
 	rangesToTest := []RangeToTest{
+		// An equality operator does not change the meaning of a partial version
+		{
+			ConstraintString: "=1.2",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{
+						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0, PreReleaseTag: "0"},
+					},
+				},
+				Join: []JoinOp{},
+			},
+		},
 		{
 			ConstraintString: "ANY",
 			Constraint: Constraint{
@@ -571,7 +598,7 @@ func TestXRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 3, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 4, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 4, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -583,7 +610,7 @@ func TestXRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -606,7 +633,7 @@ func TestXRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 3, Minor: 0, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 4, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 4, Minor: 0, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -618,7 +645,7 @@ func TestXRangeParsing(t *testing.T) {
 				Ranges: []Range{
 					{
 						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 2, Patch: 0},
-						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0, PreReleaseTag: "0"},
 					},
 				},
 				Join: []JoinOp{},
@@ -823,22 +850,49 @@ func TestRangeParsing(t *testing.T) {
 
 }
 
+func TestPreReleaseMetaDataParsing(t *testing.T) {
+
+	fmt.Printf("\n%s Testing parsing of versions with both a prerelease and metadata %s\n", "----------------", "----------------")
+
+	// Everything after the first '+' is metadata, so the desugared versions have to keep the prerelease in front of it
+	beta := versions.Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "beta", MetaData: "build"}
+	rangesToTest := []RangeToTest{
+		{ConstraintString: ">=1.2.3-beta+build", Constraint: Constraint{Ranges: []Range{{StartOp: GE, StartVersion: beta}}, Join: []JoinOp{}}},
+		{ConstraintString: "=1.2.3-beta+build", Constraint: Constraint{Ranges: []Range{{StartOp: EQ, StartVersion: beta}}, Join: []JoinOp{}}},
+		{
+			ConstraintString: "^1.2.3-beta+build",
+			Constraint: Constraint{
+				Ranges: []Range{{StartOp: GE, StartVersion: beta, EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"}}},
+				Join:   []JoinOp{},
+			},
+		},
+		{
+			ConstraintString: "~1.2.3-beta+build",
+			Constraint: Constraint{
+				Ranges: []Range{{StartOp: GE, StartVersion: beta, EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 3, Patch: 0, PreReleaseTag: "0"}}},
+				Join:   []JoinOp{},
+			},
+		},
+		{
+			ConstraintString: "1.2.3-beta+build - 2.0.0-rc.1+build.2",
+			Constraint: Constraint{
+				Ranges: []Range{{StartOp: GE, StartVersion: beta, EndOp: LE, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "rc.1", MetaData: "build.2"}}},
+				Join:   []JoinOp{},
+			},
+		},
+	}
+
+	testConstraints(t, rangesToTest)
+
+	fmt.Printf("\n")
+
+}
+
 func TestStaticParsing(t *testing.T) {
 
-	fmt.Printf("\n%s Testing static equality operator (= 5.0.0, !7.0.0) parsing %s\n", "----------------", "----------------")
+	fmt.Printf("\n%s Testing static equality operator (= 5.0.0, !=7.0.0) parsing %s\n", "----------------", "----------------")
 
 	rangesToTest := []RangeToTest{
-		// {
-		// 	ConstraintString: "!5.0.0",
-		// 	Constraint: Constraint{
-		// 		Ranges: []Range{
-		// 			{
-		// 				StartOp: NOT, StartVersion: versions.Semver{Major: 5, Minor: 0, Patch: 0},
-		// 			},
-		// 		},
-		// 		Join: []JoinOp{},
-		// 	},
-		// },
 		{
 			ConstraintString: "=5.0.0",
 			Constraint: Constraint{
@@ -850,17 +904,6 @@ func TestStaticParsing(t *testing.T) {
 				Join: []JoinOp{},
 			},
 		},
-		// {
-		// 	ConstraintString: "!5.0.0-beta.2",
-		// 	Constraint: Constraint{
-		// 		Ranges: []Range{
-		// 			{
-		// 				StartOp: NOT, StartVersion: versions.Semver{Major: 5, Minor: 0, Patch: 0, PreReleaseTag: "beta.2"},
-		// 			},
-		// 		},
-		// 		Join: []JoinOp{},
-		// 	},
-		// },
 		{
 			ConstraintString: "=5.0.0-beta.2",
 			Constraint: Constraint{
@@ -872,6 +915,67 @@ func TestStaticParsing(t *testing.T) {
 				Join: []JoinOp{},
 			},
 		},
+		{
+			ConstraintString: "!=5.0.0",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{
+						StartOp: NOT, StartVersion: versions.Semver{Major: 5, Minor: 0, Patch: 0},
+					},
+				},
+				Join: []JoinOp{},
+			},
+		},
+		{
+			ConstraintString: ">=1.0.0 !=1.3.2 <2.0.0",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{
+						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+					},
+					{
+						StartOp: NOT, StartVersion: versions.Semver{Major: 1, Minor: 3, Patch: 2},
+					},
+				},
+				Join: []JoinOp{CONJUNCTON},
+			},
+		},
+		{
+			ConstraintString: "1.x != 1.3.2 != 1.4.0 || 3.0.0",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{
+						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"},
+					},
+					{
+						StartOp: NOT, StartVersion: versions.Semver{Major: 1, Minor: 3, Patch: 2},
+					},
+					{
+						StartOp: NOT, StartVersion: versions.Semver{Major: 1, Minor: 4, Patch: 0},
+					},
+					{
+						StartOp: EQ, StartVersion: versions.Semver{Major: 3, Minor: 0, Patch: 0},
+					},
+				},
+				Join: []JoinOp{CONJUNCTON, CONJUNCTON, DISJUNCTION},
+			},
+		},
+		{
+			ConstraintString: "!=1.0.0 && !=2.0.0",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{
+						StartOp: NOT, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+					},
+					{
+						StartOp: NOT, StartVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+					},
+				},
+				Join: []JoinOp{CONJUNCTON},
+			},
+		},
 	}
 
 	testConstraints(t, rangesToTest)
@@ -879,6 +983,345 @@ func TestStaticParsing(t *testing.T) {
 	fmt.Printf("\n")
 }
 
+func TestGroupParsing(t *testing.T) {
+
+	fmt.Printf("\n%s Testing parenthesized group parsing %s\n", "----------------", "----------------")
+
+	rangesToTest := []RangeToTest{
+		{
+			ConstraintString: "(>=1.0 <1.5) || (>=2.0 <2.3)",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{
+						StartOp: GE, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 1, Minor: 5, Patch: 0},
+					},
+					{
+						StartOp: GE, StartVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0},
+						EndOp: LT, EndVersion: versions.Semver{Major: 2, Minor: 3, Patch: 0},
+					},
+				},
+				Join: []JoinOp{DISJUNCTION},
+			},
+		},
+		{
+			ConstraintString: "(1.0.0 || 2.0.0) && <1.5.0",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{StartOp: EQ, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0}},
+					{StartOp: LT, StartVersion: versions.Semver{Major: 1, Minor: 5, Patch: 0}},
+					{StartOp: EQ, StartVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0}},
+					{StartOp: LT, StartVersion: versions.Semver{Major: 1, Minor: 5, Patch: 0}},
+				},
+				Join: []JoinOp{CONJUNCTON, DISJUNCTION, CONJUNCTON},
+			},
+		},
+		{
+			ConstraintString: "((( =1.0.0 )))",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{StartOp: EQ, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0}},
+				},
+				Join: []JoinOp{},
+			},
+		},
+		{
+			ConstraintString: "<1.0.0||(>2.0.0 && <3.0.0)",
+			Constraint: Constraint{
+				Ranges: []Range{
+					{StartOp: LT, StartVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0}},
+					{StartOp: GT, StartVersion: versions.Semver{Major: 2, Minor: 0, Patch: 0}},
+					{StartOp: LT, StartVersion: versions.Semver{Major: 3, Minor: 0, Patch: 0}},
+				},
+				Join: []JoinOp{DISJUNCTION, CONJUNCTON},
+			},
+		},
+	}
+
+	testConstraints(t, rangesToTest)
+
+	fmt.Printf("\n")
+}
+
+type GroupStringToTest struct {
+	ConstraintString string
+	ExpectedString   string
+}
+
+func TestGroupString(t *testing.T) {
+
+	fmt.Printf("\n%s Testing string representation of groups %s\n", "----------------", "----------------")
+
+	stringsToTest := []GroupStringToTest{
+		{ConstraintString: "(>=1.0 <1.5) || (>=2.0 <2.3)", ExpectedString: ">=1.0.0 <1.5.0 || >=2.0.0 <2.3.0"},
+		{ConstraintString: "(1.x || 2.x) && <1.5.0", ExpectedString: "(>=1.0.0 <2.0.0-0 || >=2.0.0 <3.0.0-0) && <1.5.0"},
+		{ConstraintString: ">=1.0.0 && (<1.2.0 || >1.4.0 && <1.6.0)", ExpectedString: ">=1.0.0 && (<1.2.0 || >1.4.0 <1.6.0)"},
+	}
+
+	for _, stringToTest := range stringsToTest {
+		fmt.Printf("\nTesting string representation of '%s'\n", stringToTest.ConstraintString)
+
+		parsedConstraint, err := ParseConstraint(stringToTest.ConstraintString)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", stringToTest.ConstraintString, err)
+		}
+
+		if parsedConstraint.String() != stringToTest.ExpectedString {
+			fmt.Printf("✗ Failed. Expected: '%s', but got: '%s'\n", stringToTest.ExpectedString, parsedConstraint.String())
+			t.Errorf("✗ Failed. Expected: '%s', but got: '%s'\n", stringToTest.ExpectedString, parsedConstraint.String())
+			continue
+		}
+
+		// The string representation parses into the same constraint
+		reparsed, err := ParseConstraint(parsedConstraint.String())
+		if err != nil || !compareConstraint(reparsed, parsedConstraint) {
+			fmt.Printf("✗ Failed. '%s' does not parse into the same constraint. %v\n", parsedConstraint.String(), err)
+			t.Errorf("✗ Failed. '%s' does not parse into the same constraint. %v\n", parsedConstraint.String(), err)
+			continue
+		}
+		fmt.Println("✓ Success")
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestInvalidGroupParsing(t *testing.T) {
+
+	fmt.Printf("\n%s Testing invalid parenthesized groups %s\n", "----------------", "----------------")
+
+	invalidConstraints := []string{
+		"(>=1.0.0",
+		">=1.0.0)",
+		")>=1.0.0(",
+		"1.x || ()",
+		"( || 1.x)",
+		"(1.x && )",
+		"(1.x) (2.x)",
+		"(1.x) 2.x",
+		"1.x (2.x)",
+		">= (1.0.0)",
+	}
+
+	for _, invalidConstraint := range invalidConstraints {
+		fmt.Printf("\nTesting invalid constraint: '%s'\n", invalidConstraint)
+		_, err := ParseConstraint(invalidConstraint)
+		if err == nil {
+			fmt.Printf("✗ Failed. Expected an error for '%s'\n", invalidConstraint)
+			t.Errorf("✗ Failed. Expected an error for '%s'\n", invalidConstraint)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestInvalidExclusionParsing(t *testing.T) {
+
+	fmt.Printf("\n%s Testing invalid exclusions (!=) %s\n", "----------------", "----------------")
+
+	invalidConstraints := []string{
+		"!=",
+		"!= 1.x",
+		"!= >= 1.0.0",
+		">= != 1.0.0",
+		"!= 1.0.0 - 2.0.0",
+		// Only != excludes a version, a lone ! is not an operator
+		"!5.0.0",
+		"!5.0.0-beta.2",
+		"! 5.0.0",
+	}
+
+	for _, invalidConstraint := range invalidConstraints {
+		fmt.Printf("\nTesting invalid constraint: '%s'\n", invalidConstraint)
+		_, err := ParseConstraint(invalidConstraint)
+		if err == nil {
+			fmt.Printf("✗ Failed. Expected an error for '%s'\n", invalidConstraint)
+			t.Errorf("✗ Failed. Expected an error for '%s'\n", invalidConstraint)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+type ParseErrorToTest struct {
+	ConstraintString string
+	Offset           int
+	Literal          string
+	Code             ParseErrorCode
+	Expected         []TokenClass
+	Sentinel         error
+}
+
+func TestParseError(t *testing.T) {
+
+	fmt.Printf("\n%s Testing typed parse errors %s\n", "----------------", "----------------")
+
+	errorsToTest := []ParseErrorToTest{
+		{ConstraintString: ">= 1.0.0 $ 2", Offset: 9, Literal: "$", Code: ERR_ILLEGAL_TOKEN, Sentinel: ErrIllegalCharacterInConstraint},
+		{ConstraintString: "&& 1.0.0", Offset: 0, Literal: "&&", Code: ERR_MISPLACED_JOIN, Expected: []TokenClass{VERSION_CLASS, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, OPEN_PARENTHESIS_CLASS}, Sentinel: ErrInvalidConstraint},
+		{ConstraintString: "1.x || >= 5.0.0 &&", Offset: 18, Literal: "", Code: ERR_MISPLACED_JOIN, Expected: []TokenClass{VERSION_CLASS, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, OPEN_PARENTHESIS_CLASS}, Sentinel: ErrInvalidConstraint},
+		{ConstraintString: "(1.x || 2.x", Offset: 11, Literal: "", Code: ERR_UNBALANCED_PARENTHESIS, Expected: []TokenClass{CLOSE_PARENTHESIS_CLASS}, Sentinel: ErrInvalidConstraint},
+		{ConstraintString: ">= 1.0.0 ) || ( 2.x", Offset: 9, Literal: ")", Code: ERR_UNBALANCED_PARENTHESIS, Sentinel: ErrInvalidConstraint},
+		{ConstraintString: "1.x || ()", Offset: 8, Literal: ")", Code: ERR_EMPTY_GROUP, Expected: []TokenClass{VERSION_CLASS, RANGE_OPERATOR_CLASS, EQUALITY_OPERATOR_CLASS, OPEN_PARENTHESIS_CLASS}, Sentinel: ErrInvalidConstraint},
+		{ConstraintString: "1.0.0 && 2.0.0", Offset: 9, Literal: "2.0.0", Code: ERR_CONTRADICTING_VERSIONS, Sentinel: ErrInvalidConstraint},
+		{ConstraintString: "!5.0.0", Offset: 0, Literal: "!", Code: ERR_ILLEGAL_TOKEN, Sentinel: ErrIllegalCharacterInConstraint},
+		{ConstraintString: "!= 1.x", Offset: 3, Literal: "1.x", Code: ERR_INVALID_EXCLUSION, Expected: []TokenClass{STATIC_VERSION_CLASS}, Sentinel: ErrInvalidConstraint},
+		{ConstraintString: "1.0.0 - ", Offset: 8, Literal: "", Code: ERR_INVALID_HYPHEN_RANGE, Expected: []TokenClass{STATIC_VERSION_CLASS}, Sentinel: ErrInvalidConstraint},
+		{ConstraintString: "1.x || ~a.b.c", Offset: 7, Literal: "~a.b.c", Code: ERR_INVALID_VERSION, Sentinel: ErrInvalidVersion},
+		// The highest possible part cannot be incremented for the end of the range
+		{ConstraintString: "^18446744073709551615.0.0", Offset: 0, Literal: "^18446744073709551615.0.0", Code: ERR_INVALID_VERSION, Sentinel: versions.ErrVersionPartOverflow},
+		{ConstraintString: "~1.18446744073709551615", Offset: 0, Literal: "~1.18446744073709551615", Code: ERR_INVALID_VERSION, Sentinel: versions.ErrVersionPartOverflow},
+		{ConstraintString: "18446744073709551615.x", Offset: 0, Literal: "18446744073709551615.x", Code: ERR_INVALID_VERSION, Sentinel: versions.ErrVersionPartOverflow},
+		{ConstraintString: "1.0.0 - 1.18446744073709551615", Offset: 0, Literal: "1.0.0 - 1.18446744073709551615", Code: ERR_INVALID_VERSION, Sentinel: versions.ErrVersionPartOverflow},
+		// 20 groups of two ranges would expand into 20 * 2^20 ranges
+		{ConstraintString: strings.Repeat("(1.x || 2.x) && ", 19) + "(1.x || 2.x)", Offset: 0, Literal: "", Code: ERR_TOO_COMPLEX, Sentinel: ErrConstraintTooComplex},
+	}
+
+	for _, errorToTest := range errorsToTest {
+		fmt.Printf("\nTesting parse error of '%s'\n", errorToTest.ConstraintString)
+
+		_, err := ParseConstraint(errorToTest.ConstraintString)
+
+		var parseError *ParseError
+		if !errors.As(err, &parseError) {
+			fmt.Printf("✗ Failed. Expected a *ParseError, but got: %v\n", err)
+			t.Errorf("✗ Failed. Expected a *ParseError, but got: %v\n", err)
+			continue
+		}
+
+		if parseError.Offset != errorToTest.Offset || parseError.Literal != errorToTest.Literal || parseError.Code != errorToTest.Code || !slices.Equal(parseError.Expected, errorToTest.Expected) {
+			fmt.Printf("✗ Failed. Expected: %d '%s' %s %v, but got: %d '%s' %s %v\n", errorToTest.Offset, errorToTest.Literal, errorToTest.Code, errorToTest.Expected, parseError.Offset, parseError.Literal, parseError.Code, parseError.Expected)
+			t.Errorf("✗ Failed. Expected: %d '%s' %s %v, but got: %d '%s' %s %v\n", errorToTest.Offset, errorToTest.Literal, errorToTest.Code, errorToTest.Expected, parseError.Offset, parseError.Literal, parseError.Code, parseError.Expected)
+		} else if !errors.Is(err, errorToTest.Sentinel) {
+			fmt.Printf("✗ Failed. Expected error to wrap '%s'\n", errorToTest.Sentinel)
+			t.Errorf("✗ Failed. Expected error to wrap '%s'\n", errorToTest.Sentinel)
+		} else if !errors.Is(err, ErrInvalidConstraint) {
+			// Every parse failure can be matched by the same sentinel
+			fmt.Printf("✗ Failed. Expected error to wrap '%s'\n", ErrInvalidConstraint)
+			t.Errorf("✗ Failed. Expected error to wrap '%s'\n", ErrInvalidConstraint)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+type DiagnosticsToTest struct {
+	ConstraintString string
+	Expected         []Diagnostic
+}
+
+func TestParseDiagnostics(t *testing.T) {
+
+	fmt.Printf("\n%s Testing diagnostics of the parser %s\n", "----------------", "----------------")
+
+	diagnosticsToTest := []DiagnosticsToTest{
+		{ConstraintString: "1.2.3 - 2.3.4", Expected: []Diagnostic{}},
+		{
+			ConstraintString: "1.2 - 2.3.4",
+			Expected: []Diagnostic{
+				{Severity: SEVERITY_WARNING, Code: WARN_PARTIAL_HYPHEN_VERSION, Constraint: "1.2 - 2.3.4", Offset: 0, Literal: "1.2", Message: "partial version in hyphen range"},
+			},
+		},
+		{
+			ConstraintString: "^1.0.0 || 1 - 2",
+			Expected: []Diagnostic{
+				{Severity: SEVERITY_WARNING, Code: WARN_PARTIAL_HYPHEN_VERSION, Constraint: "^1.0.0 || 1 - 2", Offset: 10, Literal: "1", Message: "partial version in hyphen range"},
+				{Severity: SEVERITY_WARNING, Code: WARN_PARTIAL_HYPHEN_VERSION, Constraint: "^1.0.0 || 1 - 2", Offset: 14, Literal: "2", Message: "partial version in hyphen range"},
+			},
+		},
+		{
+			ConstraintString: "&& 1.0.0",
+			Expected: []Diagnostic{
+				{Severity: SEVERITY_ERROR, Code: DiagnosticCode(ERR_MISPLACED_JOIN), Constraint: "&& 1.0.0", Offset: 0, Literal: "&&"},
+			},
+		},
+	}
+
+	for _, diagnosticToTest := range diagnosticsToTest {
+		fmt.Printf("\nTesting diagnostics of '%s'\n", diagnosticToTest.ConstraintString)
+
+		reported := []Diagnostic{}
+		options := ParseOptions{Diagnostics: DiagnosticsFunc(func(d Diagnostic) {
+			// The message of errors is the message of the ParseError, which is tested separately
+			if d.Severity == SEVERITY_ERROR {
+				d.Message = ""
+			}
+			reported = append(reported, d)
+		})}
+		ParseConstraintWithOptions(diagnosticToTest.ConstraintString, options)
+
+		if !slices.Equal(reported, diagnosticToTest.Expected) {
+			fmt.Printf("✗ Failed. Expected: %v, but got: %v\n", diagnosticToTest.Expected, reported)
+			t.Errorf("✗ Failed. Expected: %v, but got: %v\n", diagnosticToTest.Expected, reported)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+type LooseParsingToTest struct {
+	ConstraintString string
+	Expected         string
+	ExpectedRewrites []string
+}
+
+func TestLooseParsing(t *testing.T) {
+
+	fmt.Printf("\n%s Testing loose constraint parsing %s\n", "----------------", "----------------")
+
+	constraintsToTest := []LooseParsingToTest{
+		{ConstraintString: "=v1.2.3", Expected: "=1.2.3", ExpectedRewrites: []string{}},
+		{ConstraintString: "v 1.2.3", Expected: "=1.2.3", ExpectedRewrites: []string{"0:v "}},
+		{ConstraintString: "1.2.3beta", Expected: "=1.2.3-beta", ExpectedRewrites: []string{"5:"}},
+		{ConstraintString: "==1.0", Expected: ">=1.0.0 <1.1.0-0", ExpectedRewrites: []string{"0:=="}},
+		{ConstraintString: "^1.2.3\r\n", Expected: ">=1.2.3 <2.0.0-0", ExpectedRewrites: []string{"6:\r"}},
+		{ConstraintString: ">= V1.0.0beta <2", Expected: ">=1.0.0-beta <2.0.0", ExpectedRewrites: []string{"3:V", "9:"}},
+		{ConstraintString: ">=1.0.0 || == v 2.0.0rc.1\r", Expected: ">=1.0.0 || =2.0.0-rc.1", ExpectedRewrites: []string{"11:==", "14:v ", "21:", "25:\r"}},
+		{ConstraintString: "^v1.2.3", Expected: ">=1.2.3 <2.0.0-0", ExpectedRewrites: []string{}},
+		{ConstraintString: "^v1.2", Expected: ">=1.2.0 <2.0.0-0", ExpectedRewrites: []string{}},
+		{ConstraintString: "~v1.2.3", Expected: ">=1.2.3 <1.3.0-0", ExpectedRewrites: []string{}},
+		{ConstraintString: "v1.2.3 - v2", Expected: ">=1.2.3 <3.0.0-0", ExpectedRewrites: []string{}},
+	}
+
+	for _, constraintToTest := range constraintsToTest {
+		fmt.Printf("\nTesting loose parsing of %q\n", constraintToTest.ConstraintString)
+
+		if _, err := ParseConstraint(constraintToTest.ConstraintString); err == nil && len(constraintToTest.ExpectedRewrites) > 0 {
+			fmt.Printf("✗ Failed. Expected %q to be rejected outside of loose mode\n", constraintToTest.ConstraintString)
+			t.Errorf("✗ Failed. Expected %q to be rejected outside of loose mode\n", constraintToTest.ConstraintString)
+		}
+
+		rewrites := []string{}
+		options := ParseOptions{Loose: true, Diagnostics: DiagnosticsFunc(func(d Diagnostic) {
+			if d.Code == INFO_LOOSE_REWRITE {
+				rewrites = append(rewrites, fmt.Sprintf("%d:%s", d.Offset, d.Literal))
+			}
+		})}
+		c, err := ParseConstraintWithOptions(constraintToTest.ConstraintString, options)
+		if err != nil {
+			fmt.Printf("✗ Failed. Expected: '%s', but got error: %s\n", constraintToTest.Expected, err)
+			t.Errorf("✗ Failed. Expected: '%s', but got error: %s\n", constraintToTest.Expected, err)
+		} else if c.String() != constraintToTest.Expected {
+			fmt.Printf("✗ Failed. Expected: '%s', but got: '%s'\n", constraintToTest.Expected, c.String())
+			t.Errorf("✗ Failed. Expected: '%s', but got: '%s'\n", constraintToTest.Expected, c.String())
+		} else if !slices.Equal(rewrites, constraintToTest.ExpectedRewrites) {
+			fmt.Printf("✗ Failed. Expected rewrites: %q, but got: %q\n", constraintToTest.ExpectedRewrites, rewrites)
+			t.Errorf("✗ Failed. Expected rewrites: %q, but got: %q\n", constraintToTest.ExpectedRewrites, rewrites)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
 func testConstraints(t *testing.T, rangesToTest []RangeToTest) {
 	for _, constraintToTest := range rangesToTest {
 		fmt.Printf("\nTesting range parsing: '%s'\n", constraintToTest.ConstraintString)
@@ -897,3 +1340,97 @@ func testConstraints(t *testing.T, rangesToTest []RangeToTest) {
 		}
 	}
 }
+
+type ComposerParsingToTest struct {
+	ConstraintString  string
+	Expected          string
+	ExpectedStability string
+	ExpectedCode      ParseErrorCode
+}
+
+func TestComposerParsing(t *testing.T) {
+
+	fmt.Printf("\n%s Testing Composer constraint parsing %s\n", "----------------", "----------------")
+
+	constraintsToTest := []ComposerParsingToTest{
+		{ConstraintString: "^1.2, <1.5", Expected: ">=1.2.0-0 <2.0.0-0 && <1.5.0-0"},
+		{ConstraintString: ">=1.0 <2.0", Expected: ">=1.0.0-0 && <2.0.0-0"},
+		{ConstraintString: ">= 1.0, < 2.0 || 3.0.*", Expected: ">=1.0.0-0 && <2.0.0-0 || >=3.0.0-0 <3.1.0-0"},
+		{ConstraintString: "1.0.* | 2.*", Expected: ">=1.0.0-0 <1.1.0-0 || >=2.0.0-0 <3.0.0-0"},
+		{ConstraintString: "^1.0 || ^2.0, !=2.1.0", Expected: ">=1.0.0-0 <2.0.0-0 || >=2.0.0-0 <3.0.0-0 && !=2.1.0"},
+		{ConstraintString: "~1", Expected: ">=1.0.0-0 <2.0.0-0"},
+		{ConstraintString: "~1.2", Expected: ">=1.2.0-0 <2.0.0-0"},
+		{ConstraintString: "~1.2.3", Expected: ">=1.2.3-0 <1.3.0-0"},
+		{ConstraintString: "^0.3", Expected: ">=0.3.0-0 <0.4.0-0"},
+		{ConstraintString: "^0.0.3", Expected: ">=0.0.3-0 <0.0.4-0"},
+		{ConstraintString: "1.0 - 2.0", Expected: ">=1.0.0-0 <2.1.0-0"},
+		{ConstraintString: "1.0.0 - 2.1.0", Expected: ">=1.0.0-0 <=2.1.0"},
+		{ConstraintString: "1.2", Expected: "=1.2.0"},
+		{ConstraintString: "v1.2.3-beta1", Expected: "=1.2.3-beta1", ExpectedStability: "beta"},
+		{ConstraintString: "<>1.5", Expected: "!=1.5.0"},
+		{ConstraintString: "*", Expected: ">=0.0.0-0"},
+		// A wildcard with the dev modifier is the head of a branch, not a range of versions
+		{ConstraintString: "1.0.x-dev", Expected: "=1.0.9999999.9999999-dev", ExpectedStability: "dev"},
+		{ConstraintString: "!=1.0.x-dev", Expected: "!=1.0.9999999.9999999-dev", ExpectedStability: "dev"},
+		{ConstraintString: "==1.0.0-dev", Expected: "=1.0.0-dev", ExpectedStability: "dev"},
+		{ConstraintString: ">=5.3@dev", Expected: ">=5.3.0-0", ExpectedStability: "dev"},
+		{ConstraintString: ">5.3@beta", Expected: ">5.3.0-beta", ExpectedStability: "beta"},
+		{ConstraintString: "@rc", Expected: ">=0.0.0-0", ExpectedStability: "RC"},
+		{ConstraintString: "^2.0@beta || ^1.0@RC", Expected: ">=2.0.0-0 <3.0.0-0 || >=1.0.0-0 <2.0.0-0", ExpectedStability: "beta"},
+		{ConstraintString: "^2.0-alpha2, <2.5", Expected: ">=2.0.0-alpha2 <3.0.0-0 && <2.5.0-0", ExpectedStability: "alpha"},
+		{ConstraintString: "dev-main", Expected: "=dev-main", ExpectedStability: "dev"},
+		{ConstraintString: "==dev-feature/login || ^1.0", Expected: "=dev-feature/login || >=1.0.0-0 <2.0.0-0", ExpectedStability: "dev"},
+		{ConstraintString: "dev-main as 1.0.x-dev", Expected: "=dev-main", ExpectedStability: "dev"},
+		{ConstraintString: ">=1.0.0-rc1", Expected: ">=1.0.0-RC1", ExpectedStability: "RC"},
+		{ConstraintString: ">=1.0.0-b.1", Expected: ">=1.0.0-beta1", ExpectedStability: "beta"},
+		{ConstraintString: "~1.0.0-pl1", Expected: ">=1.0.0-patch1 <1.1.0-0"},
+		// The fourth part is the revision
+		{ConstraintString: "1.2.3.4", Expected: "=1.2.3.4"},
+		{ConstraintString: "~1.2.3.4", Expected: ">=1.2.3.4-0 <1.2.4-0"},
+		{ConstraintString: "^0.0.0.4", Expected: ">=0.0.0.4-0 <0.0.0.5-0"},
+		{ConstraintString: ">=1.2.3.4-beta1, !=1.2.3.5", Expected: ">=1.2.3.4-beta1 && !=1.2.3.5", ExpectedStability: "beta"},
+		{ConstraintString: "1.2.3.*", Expected: ">=1.2.3-0 <1.2.4-0"},
+
+		{ConstraintString: "1.0,", ExpectedCode: ERR_MISPLACED_JOIN},
+		{ConstraintString: "| 1.0", ExpectedCode: ERR_MISPLACED_JOIN},
+		{ConstraintString: "1.0 -2.0", ExpectedCode: ERR_INVALID_VERSION},
+		{ConstraintString: "1.*.3", ExpectedCode: ERR_INVALID_VERSION},
+		{ConstraintString: ">=dev-master", ExpectedCode: ERR_INVALID_VERSION},
+		{ConstraintString: "^dev-master", ExpectedCode: ERR_INVALID_VERSION},
+		{ConstraintString: "^1.0.0-foo", ExpectedCode: ERR_INVALID_VERSION},
+		{ConstraintString: "1.2.3.4.5", ExpectedCode: ERR_INVALID_VERSION},
+		// The highest possible part cannot be bumped to the end of the range
+		{ConstraintString: "^18446744073709551615.0", ExpectedCode: ERR_INVALID_VERSION},
+		{ConstraintString: "1.18446744073709551615.*", ExpectedCode: ERR_INVALID_VERSION},
+		{ConstraintString: "1.0 - 18446744073709551615", ExpectedCode: ERR_INVALID_VERSION},
+	}
+
+	for _, constraintToTest := range constraintsToTest {
+		fmt.Printf("\nTesting Composer parsing of %q\n", constraintToTest.ConstraintString)
+
+		c, err := ParseConstraintWithEcosystem(constraintToTest.ConstraintString, "composer")
+
+		if constraintToTest.ExpectedCode != "" {
+			var parseError *ParseError
+			if !errors.As(err, &parseError) || parseError.Code != constraintToTest.ExpectedCode {
+				fmt.Printf("✗ Failed. Expected error '%s', but got: %v\n", constraintToTest.ExpectedCode, err)
+				t.Errorf("✗ Failed. Expected error '%s', but got: %v\n", constraintToTest.ExpectedCode, err)
+			} else {
+				fmt.Println("✓ Success")
+			}
+			continue
+		}
+
+		if err != nil {
+			fmt.Printf("✗ Failed. Expected: '%s', but got error: %s\n", constraintToTest.Expected, err)
+			t.Errorf("✗ Failed. Expected: '%s', but got error: %s\n", constraintToTest.Expected, err)
+		} else if c.String() != constraintToTest.Expected || c.Stability != constraintToTest.ExpectedStability {
+			fmt.Printf("✗ Failed. Expected: '%s' (%s), but got: '%s' (%s)\n", constraintToTest.Expected, constraintToTest.ExpectedStability, c.String(), c.Stability)
+			t.Errorf("✗ Failed. Expected: '%s' (%s), but got: '%s' (%s)\n", constraintToTest.Expected, constraintToTest.ExpectedStability, c.String(), c.Stability)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
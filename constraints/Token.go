@@ -15,7 +15,7 @@ const (
 	LE                 Token = "LE"                // <=
 	GT                 Token = "GT"                // >
 	GE                 Token = "GE"                // >=
-	NOT                Token = "NOT"               // !
+	NOT                Token = "NOT"               // !=
 	TILDE              Token = "TILDE"             // ~
 	CARET              Token = "CARET"             // ^
 	OPEN_PARENTHESIS   Token = "OPEN_PARENTHESIS"  // (
@@ -32,7 +32,7 @@ const (
 )
 
 func IsOperatorToken(token Token) bool {
-	return token == EQ || token == LT || token == LE || token == GT || token == GE || token == TILDE || token == CARET || token == OPEN_PARENTHESIS || token == CLOSE_PARENTHESIS || token == AND || token == OR || token == HYPHEN
+	return token == EQ || token == NOT || token == LT || token == LE || token == GT || token == GE || token == TILDE || token == CARET || token == OPEN_PARENTHESIS || token == CLOSE_PARENTHESIS || token == AND || token == OR || token == HYPHEN
 }
 
 func IsRangeToken(token Token) bool {
@@ -40,7 +40,7 @@ func IsRangeToken(token Token) bool {
 }
 
 func IsEqualityToken(token Token) bool {
-	return token == EQ
+	return token == EQ || token == NOT
 }
 
 func IsJoinToken(token Token) bool {
@@ -59,6 +59,8 @@ func (token Token) toString() string {
 		return ">"
 	case GE:
 		return ">="
+	case NOT:
+		return "!="
 	case TILDE:
 		return "~"
 	case CARET:
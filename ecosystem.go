@@ -0,0 +1,153 @@
+package semver
+
+import (
+	"sync"
+
+	constraints "github.com/CodeClarityCE/utility-node-semver/constraints"
+	evaluator "github.com/CodeClarityCE/utility-node-semver/evaluator"
+	versions "github.com/CodeClarityCE/utility-node-semver/versions"
+)
+
+// EcosystemType represents different package ecosystem types
+type EcosystemType string
+
+const (
+	// NodeJS represents Node.js/npm ecosystem with Node.js semver rules
+	NodeJS EcosystemType = versions.NODEJS_ECOSYSTEM
+	// Composer represents PHP/Composer ecosystem with Composer semver rules
+	Composer EcosystemType = versions.COMPOSER_ECOSYSTEM
+)
+
+// An Ecosystem holds the versioning rules of a package ecosystem
+// Ecosystems are registered by their type, such that the functions of this package taking an EcosystemType follow their rules, see RegisterEcosystem
+type Ecosystem interface {
+	// Parses a version literal of the ecosystem
+	ParseVersion(versionLiteral string) (versions.Semver, error)
+	// Parses a constraint of the ecosystem
+	ParseConstraint(constraintString string) (constraints.Constraint, error)
+	// Compares two versions of the ecosystem, and returns 0 if v1 = v2, 1 if v1 > v2 and -1 otherwise
+	Compare(v1 versions.Semver, v2 versions.Semver) int
+	// Returns true if the version satisfies the constraint as per the prerelease policy of the ecosystem
+	// includePreReleases lifts the policy, such that prerelease versions satisfy the constraint as their release would, see Satisfies
+	Satisfies(v versions.Semver, c constraints.Constraint, includePreReleases bool) bool
+	// Returns the version as the ecosystem writes it
+	Format(v versions.Semver) string
+}
+
+var (
+	ecosystemsMutex sync.RWMutex
+	ecosystems      = map[EcosystemType]Ecosystem{
+		NodeJS:   nodeJSEcosystem{},
+		Composer: composerEcosystem{},
+	}
+)
+
+// Registers the ecosystem for the given type, replacing the ecosystem registered before, if any
+// NodeJS and Composer are registered by default
+//
+//	ex: RegisterEcosystem("cargo", cargoEcosystem{}) would make semver.ParseSemverWithEcosystem(v, "cargo") parse cargo versions
+//
+// A registration only affects the functions of this package taking an EcosystemType: ParseSemverWithEcosystem, ParseConstraintWithEcosystem,
+// FormatWithEcosystem, SatisfiesWithEcosystem, MaxSatisfyingStringsWithEcosystem and SortStringsWithEcosystem.
+// The parsers of the versions and constraints packages take the ecosystem as a string and only know the built-in Composer rules,
+// e.g. versions.ParseSemverWithEcosystem(v, "cargo") parses nodesemver versions whatever is registered.
+// Likewise, Semver.Compare only knows the built-in rules, the versions of a registered ecosystem are compared by its Compare.
+func RegisterEcosystem(ecosystemType EcosystemType, ecosystem Ecosystem) {
+	ecosystemsMutex.Lock()
+	defer ecosystemsMutex.Unlock()
+	ecosystems[ecosystemType] = ecosystem
+}
+
+// Returns the ecosystem registered for the given type
+// Types that are not registered follow the rules of NodeJS
+func LookupEcosystem(ecosystemType EcosystemType) Ecosystem {
+	ecosystemsMutex.RLock()
+	defer ecosystemsMutex.RUnlock()
+	if ecosystem, ok := ecosystems[ecosystemType]; ok {
+		return ecosystem
+	}
+	return ecosystems[NodeJS]
+}
+
+// Returns true if an ecosystem is registered for the given type
+func IsRegisteredEcosystem(ecosystemType EcosystemType) bool {
+	ecosystemsMutex.RLock()
+	defer ecosystemsMutex.RUnlock()
+	_, ok := ecosystems[ecosystemType]
+	return ok
+}
+
+// The rules of nodesemver, where prereleases only satisfy comparators with a prerelease of the same tuple
+type nodeJSEcosystem struct{}
+
+func (nodeJSEcosystem) ParseVersion(versionLiteral string) (versions.Semver, error) {
+	return versions.ParseSemverWithEcosystem(versionLiteral, versions.NODEJS_ECOSYSTEM)
+}
+
+func (nodeJSEcosystem) ParseConstraint(constraintString string) (constraints.Constraint, error) {
+	return constraints.ParseConstraintWithEcosystem(constraintString, versions.NODEJS_ECOSYSTEM)
+}
+
+func (nodeJSEcosystem) Compare(v1 versions.Semver, v2 versions.Semver) int {
+	return v1.Compare(v2, false)
+}
+
+func (nodeJSEcosystem) Satisfies(v versions.Semver, c constraints.Constraint, includePreReleases bool) bool {
+	return evaluator.Satisfies(v, c, includePreReleases)
+}
+
+func (nodeJSEcosystem) Format(v versions.Semver) string {
+	return v.Format(versions.CANONICAL_FORMAT)
+}
+
+// The rules of Composer, where prereleases are not excluded by the constraint but by the minimum-stability, see SatisfiesComposer
+type composerEcosystem struct{}
+
+func (composerEcosystem) ParseVersion(versionLiteral string) (versions.Semver, error) {
+	return versions.ParseSemverWithEcosystem(versionLiteral, versions.COMPOSER_ECOSYSTEM)
+}
+
+func (composerEcosystem) ParseConstraint(constraintString string) (constraints.Constraint, error) {
+	return constraints.ParseConstraintWithEcosystem(constraintString, versions.COMPOSER_ECOSYSTEM)
+}
+
+func (composerEcosystem) Compare(v1 versions.Semver, v2 versions.Semver) int {
+	return v1.Compare(v2, false)
+}
+
+// Following the default minimum-stability of Composer, stable, or the least minimum-stability, dev, if prereleases are included
+// Both are valid minimum-stabilities, so no error can be returned
+func (composerEcosystem) Satisfies(v versions.Semver, c constraints.Constraint, includePreReleases bool) bool {
+	options := evaluator.ComposerOptions{}
+	if includePreReleases {
+		options.MinimumStability = versions.ComposerStabilities[0]
+	}
+	satisfies, _ := evaluator.SatisfiesComposer(v, c, options)
+	return satisfies
+}
+
+func (composerEcosystem) Format(v versions.Semver) string {
+	return v.Format(versions.NATIVE_FORMAT)
+}
+
+// Returns the version as the given ecosystem writes it
+//
+//	ex: version '1.2.3.4' would return '1.2.3.4' for Composer, and '1.2.3' for NodeJS
+func FormatWithEcosystem(v versions.Semver, ecosystem EcosystemType) string {
+	return LookupEcosystem(ecosystem).Format(v)
+}
+
+// Takes a version and a constraint of the ecosystem
+// Returns true if the version satisfies the constraint as per the prerelease policy of the ecosystem
+//
+//	ex: constraint '^1.0' and version '1.1.0-RC1' would return false for NodeJS, and false for Composer, whose minimum-stability is stable
+//	ex: constraint '^1.0@RC' and version '1.1.0-RC1' would return true for Composer
+//
+// includePreReleases lifts the prerelease policy, as in Satisfies for NodeJS, and as the minimum-stability dev for Composer
+//
+//	ex: constraint '^1.0', version '1.1.0-RC1' and includePreReleases would return true for NodeJS and Composer
+//
+// Composer constraints are evaluated as by SatisfiesComposer with the default options, use SatisfiesComposer for another minimum-stability
+func SatisfiesWithEcosystem(v versions.Semver, c constraints.Constraint, ecosystem EcosystemType, includePreReleases bool) bool {
+	return LookupEcosystem(ecosystem).Satisfies(v, c, includePreReleases)
+}
@@ -1,7 +1,13 @@
 package semver
 
 import (
+	"errors"
+	"slices"
+	"strings"
 	"testing"
+
+	evaluator "github.com/CodeClarityCE/utility-node-semver/evaluator"
+	versions "github.com/CodeClarityCE/utility-node-semver/versions"
 )
 
 func TestComposerVersionParsing(t *testing.T) {
@@ -9,15 +15,15 @@ func TestComposerVersionParsing(t *testing.T) {
 		version     string
 		ecosystem   EcosystemType
 		expectDev   bool
-		expectMajor int
-		expectMinor int
-		expectPatch int
+		expectMajor uint64
+		expectMinor uint64
+		expectPatch uint64
 	}{
 		{"1.2.3", NodeJS, false, 1, 2, 3},
 		{"1.2.3", Composer, false, 1, 2, 3},
-		{"dev-master", Composer, true, 999, 999, 999},
-		{"dev-feature-branch", Composer, true, 999, 999, 999},
-		{"1.0.x-dev", Composer, true, 1, 0, 999},
+		{"dev-master", Composer, true, 9999999, 9999999, 9999999},
+		{"dev-feature-branch", Composer, true, 9999999, 9999999, 9999999},
+		{"1.0.x-dev", Composer, true, 1, 0, 9999999},
 		{"v2.1.0", NodeJS, false, 2, 1, 0},
 		{"v2.1.0", Composer, false, 2, 1, 0},
 	}
@@ -77,6 +83,47 @@ func TestComposerDevVersionComparison(t *testing.T) {
 	}
 }
 
+func TestComposerConstraintSatisfaction(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"~1.2", "1.9.0", true},    // Composer tilde allows the minor part to increase
+		{"~1.2", "2.0.0", false},   // But not the major part
+		{"~1.2.3", "1.3.0", false}, // Unless the patch part is given
+		{"^1.2, <1.5", "1.4.9", true},
+		{"^1.2, <1.5", "1.5.0", false},
+		{"1.0.* | 2.*", "2.3.0", true},
+		{"1.0.* | 2.*", "1.1.0", false},
+		{"dev-main", "dev-main", true},          // A dev branch is only matched by its exact constraint
+		{">=1.0", "dev-main", false},            // And not by numbered constraints
+		{"dev-main", "dev-feature", false},      // Nor by the constraint of another branch
+		{"^1.0", "dev-main as 1.0.x-dev", true}, // Unless it is aliased, then it compares as its alias
+		{"^2.0", "dev-main as 1.0.x-dev", false},
+		{"dev-main", "dev-main as 1.0.x-dev", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version+" satisfies "+test.constraint, func(t *testing.T) {
+			constraint, err := ParseConstraintWithEcosystem(test.constraint, Composer)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", test.constraint, err)
+			}
+
+			version, err := ParseSemverWithEcosystem(test.version, Composer)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", test.version, err)
+			}
+
+			result := Satisfies(version, constraint, false)
+			if result != test.expected {
+				t.Errorf("Expected %s satisfies %s = %t, got %t", test.version, test.constraint, test.expected, result)
+			}
+		})
+	}
+}
+
 func TestBackwardCompatibility(t *testing.T) {
 	// Test that old functions still work
 	version, err := ParseSemver("1.2.3")
@@ -95,3 +142,202 @@ func TestBackwardCompatibility(t *testing.T) {
 		t.Errorf("Expected constraint original to be '>=1.0.0', got '%s'", constraint.Original)
 	}
 }
+
+// An ecosystem whose versions are tagged with a release prefix, e.g. release-1.2.3, registered by a test
+type taggedEcosystem struct {
+	nodeJSEcosystem
+}
+
+func (taggedEcosystem) ParseVersion(versionLiteral string) (versions.Semver, error) {
+	literal, ok := strings.CutPrefix(versionLiteral, "release-")
+	if !ok {
+		return versions.Semver{}, versions.ErrInvalidVersionParts
+	}
+	return versions.ParseSemverWithEcosystem(literal, versions.NODEJS_ECOSYSTEM)
+}
+
+func (taggedEcosystem) Format(v versions.Semver) string {
+	return "release-" + v.String()
+}
+
+func TestEcosystemRegistry(t *testing.T) {
+	const Tagged EcosystemType = "tagged"
+
+	if IsRegisteredEcosystem(Tagged) {
+		t.Fatalf("Expected %s not to be registered", Tagged)
+	}
+	// Ecosystems that are not registered follow the rules of NodeJS
+	if _, err := ParseSemverWithEcosystem("release-1.2.3", Tagged); err == nil {
+		t.Errorf("Expected release-1.2.3 not to be a NodeJS version")
+	}
+
+	RegisterEcosystem(Tagged, taggedEcosystem{})
+	defer func() {
+		ecosystemsMutex.Lock()
+		delete(ecosystems, Tagged)
+		ecosystemsMutex.Unlock()
+	}()
+
+	version, err := ParseSemverWithEcosystem("release-1.2.3", Tagged)
+	if err != nil {
+		t.Fatalf("Failed to parse release-1.2.3: %v", err)
+	}
+	if version.Major != 1 || version.Minor != 2 || version.Patch != 3 {
+		t.Errorf("Expected 1.2.3, got %d.%d.%d", version.Major, version.Minor, version.Patch)
+	}
+	if formatted := FormatWithEcosystem(version, Tagged); formatted != "release-1.2.3" {
+		t.Errorf("Expected release-1.2.3, got %s", formatted)
+	}
+
+	sorted, err := SortStringsWithEcosystem(1, []string{"release-1.10.0", "release-1.2.0", "release-1.9.1"}, Tagged)
+	if err != nil {
+		t.Fatalf("Failed to sort: %v", err)
+	}
+	if !slices.Equal(sorted, []string{"release-1.2.0", "release-1.9.1", "release-1.10.0"}) {
+		t.Errorf("Expected release order, got %v", sorted)
+	}
+}
+
+func TestEcosystemPreReleasePolicy(t *testing.T) {
+	tests := []struct {
+		ecosystem          EcosystemType
+		constraint         string
+		versions           []string
+		includePreReleases bool
+		expectedMax        string
+		expectedSorted     []string
+	}{
+		// nodesemver excludes prereleases of other tuples, Composer leaves them to the minimum-stability, stable by default
+		{NodeJS, "^1.0", []string{"1.0.0", "1.1.0-rc.1", "2.0.0"}, false, "1.0.0", []string{"2.0.0", "1.1.0-rc.1", "1.0.0"}},
+		{Composer, "^1.0", []string{"1.0.0", "1.1.0-RC1", "1.2.0-dev"}, false, "1.0.0", []string{"1.2.0-dev", "1.1.0-RC1", "1.0.0"}},
+		{Composer, "^1.0@RC", []string{"1.0.0", "1.1.0-RC1", "1.2.0-dev"}, false, "1.1.0-RC1", []string{"1.2.0-dev", "1.1.0-RC1", "1.0.0"}},
+		// Including prereleases lifts the policy of either ecosystem
+		{NodeJS, "^1.0", []string{"1.0.0", "1.1.0-rc.1", "2.0.0"}, true, "1.1.0-rc.1", []string{"2.0.0", "1.1.0-rc.1", "1.0.0"}},
+		{Composer, "^1.0", []string{"1.0.0", "1.1.0-RC1", "1.2.0-dev"}, true, "1.2.0-dev", []string{"1.2.0-dev", "1.1.0-RC1", "1.0.0"}},
+		// Composer versions are ordered by their stability and revision
+		{Composer, "^1.0", []string{"1.0.0-patch1", "1.0.0.1", "1.0.0", "1.0.0-dev", "dev-main"}, false, "1.0.0.1", []string{"dev-main", "1.0.0.1", "1.0.0-patch1", "1.0.0", "1.0.0-dev"}},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.ecosystem)+" "+test.constraint+" "+strings.Join(test.versions, ","), func(t *testing.T) {
+			constraint, err := ParseConstraintWithEcosystem(test.constraint, test.ecosystem)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", test.constraint, err)
+			}
+
+			max, err := MaxSatisfyingStringsWithEcosystem(test.versions, constraint, test.ecosystem, test.includePreReleases)
+			if err != nil {
+				t.Fatalf("Failed to parse versions: %v", err)
+			}
+			if formatted := FormatWithEcosystem(max, test.ecosystem); formatted != test.expectedMax {
+				t.Errorf("Expected max satisfying %s, got %s", test.expectedMax, formatted)
+			}
+
+			sorted, err := SortStringsWithEcosystem(-1, test.versions, test.ecosystem)
+			if err != nil {
+				t.Fatalf("Failed to sort: %v", err)
+			}
+			if !slices.Equal(sorted, test.expectedSorted) {
+				t.Errorf("Expected %v, got %v", test.expectedSorted, sorted)
+			}
+		})
+	}
+}
+
+func TestSatisfiesWithEcosystem(t *testing.T) {
+	tests := []struct {
+		ecosystem          EcosystemType
+		constraint         string
+		version            string
+		includePreReleases bool
+		expected           bool
+	}{
+		{NodeJS, "^1.0", "1.1.0", false, true},
+		{NodeJS, "^1.0", "1.1.0-rc.1", false, false},
+		{NodeJS, "^1.0", "1.1.0-rc.1", true, true},
+		// Composer versions below the default minimum-stability stable only satisfy constraints with a stability flag
+		{Composer, "^1.0", "1.1.0", false, true},
+		{Composer, "^1.0", "1.1.0-RC1", false, false},
+		{Composer, "^1.0@RC", "1.1.0-RC1", false, true},
+		{Composer, "^1.0@RC", "1.2.0-dev", false, false},
+		// Or with the minimum-stability dev if prereleases are included
+		{Composer, "^1.0", "1.2.0-dev", true, true},
+		{Composer, "^1.0", "2.0.0-dev", true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.ecosystem)+" "+test.constraint+" "+test.version, func(t *testing.T) {
+			constraint, err := ParseConstraintWithEcosystem(test.constraint, test.ecosystem)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", test.constraint, err)
+			}
+			version, err := ParseSemverWithEcosystem(test.version, test.ecosystem)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", test.version, err)
+			}
+
+			if result := SatisfiesWithEcosystem(version, constraint, test.ecosystem, test.includePreReleases); result != test.expected {
+				t.Errorf("Expected %t, got %t", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestMaxSatisfyingStrings(t *testing.T) {
+	tests := []struct {
+		constraint  string
+		versions    []string
+		expectedMax string
+	}{
+		// Versions that do not satisfy the constraint are ignored, whatever their order
+		{"^1.0.0", []string{"3.0.0", "1.0.0"}, "1.0.0"},
+		{"^1.0.0", []string{"1.0.0", "1.2.0", "3.0.0"}, "1.2.0"},
+		{"^1.0.0", []string{"3.0.0", "0.5.0"}, ""},
+		{"^1.0.0", []string{}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraint+" "+strings.Join(test.versions, ","), func(t *testing.T) {
+			constraint, err := ParseConstraint(test.constraint)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", test.constraint, err)
+			}
+			parsedVersions := []versions.Semver{}
+			for _, v := range test.versions {
+				parsedVersion, err := ParseSemver(v)
+				if err != nil {
+					t.Fatalf("Failed to parse %s: %v", v, err)
+				}
+				parsedVersions = append(parsedVersions, parsedVersion)
+			}
+
+			// The versions as strings give the same version as the parsed versions, whether or not it goes through the ecosystem
+			max := MaxSatisfying(parsedVersions, constraint, false)
+			if (max == versions.Semver{}) != (test.expectedMax == "") || (test.expectedMax != "" && max.String() != test.expectedMax) {
+				t.Errorf("Expected MaxSatisfying %q, got %s", test.expectedMax, max.String())
+			}
+			for name, maxSatisfyingStrings := range map[string]func() (versions.Semver, error){
+				"MaxSatisfyingStrings": func() (versions.Semver, error) {
+					return MaxSatisfyingStrings(test.versions, constraint, false)
+				},
+				"MaxSatisfyingStringsWithEcosystem": func() (versions.Semver, error) {
+					return MaxSatisfyingStringsWithEcosystem(test.versions, constraint, NodeJS, false)
+				},
+				"evaluator.MaxSatisfyingStrings": func() (versions.Semver, error) {
+					return evaluator.MaxSatisfyingStrings(test.versions, constraint, false)
+				},
+			} {
+				max, err := maxSatisfyingStrings()
+				if test.expectedMax == "" {
+					if !errors.Is(err, evaluator.ErrNoSatisfyingVersion) {
+						t.Errorf("Expected %s to return ErrNoSatisfyingVersion, got %s and %v", name, max.String(), err)
+					}
+					continue
+				}
+				if err != nil || max.String() != test.expectedMax {
+					t.Errorf("Expected %s %s, got %s and %v", name, test.expectedMax, max.String(), err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	versionTypes "github.com/CodeClarityCE/utility-node-semver/versions"
+
+	constraints "github.com/CodeClarityCE/utility-node-semver/constraints"
+)
+
+var ErrUnknownStability = errors.New("unknown stability")
+
+// ComposerOptions configure the evaluation of Composer constraints, as composer update does
+type ComposerOptions struct {
+	// The minimum-stability of the root package, one of versions.ComposerStabilities, stable if empty
+	MinimumStability string
+	// Prefers the most stable of the satisfying versions over the highest one, see MaxSatisfyingComposer
+	PreferStable bool
+}
+
+// Returns an error wrapping ErrUnknownStability if the minimum-stability is not one of versions.ComposerStabilities
+// The stability is matched case insensitively as Composer does, e.g. rc := RC
+func (options ComposerOptions) Validate() error {
+	if options.MinimumStability != "" && stabilityPrecedence(options.MinimumStability) < 0 {
+		return fmt.Errorf("%w: minimum-stability '%s', expected one of %s", ErrUnknownStability, options.MinimumStability, strings.Join(versionTypes.ComposerStabilities, ", "))
+	}
+	return nil
+}
+
+// Takes a Composer version and a Composer constraint
+// Returns true if the version satisfies the constraint and is installable, i.e. its stability is at least the minimum-stability
+// Returns an error if the options are invalid, see ComposerOptions.Validate
+//
+// The stability flag of the constraint lowers the minimum-stability for the constraint, it never raises it
+//
+//	ex: minimum-stability 'stable', constraint '^2.0' and version '2.1.0-beta1' would return false
+//	ex: minimum-stability 'stable', constraint '^2.0@beta' and version '2.1.0-beta1' would return true
+//	ex: minimum-stability 'beta', constraint '^2.0' and version '2.1.0-alpha1' would return false
+//
+// Unlike Satisfies, a prerelease version may satisfy a constraint without prereleases, as long as it is stable enough
+//
+//	ex: minimum-stability 'RC', constraint '^2.0' and version '2.1.0-RC1' would return true
+func SatisfiesComposer(v versionTypes.Semver, c constraints.Constraint, options ComposerOptions) (bool, error) {
+	if err := options.Validate(); err != nil {
+		return false, err
+	}
+	return satisfiesComposer(v, c, options), nil
+}
+
+func satisfiesComposer(v versionTypes.Semver, c constraints.Constraint, options ComposerOptions) bool {
+	if !satisfiesExpression(v, c.ExpressionTree(), true) {
+		return false
+	}
+	return stabilityPrecedence(v.ComposerStability()) >= stabilityPrecedence(minimumStability(c, options))
+}
+
+// Takes Composer versions and a Composer constraint
+// Returns the version that composer update would pick, and ErrNoSatisfyingVersion if no version is installable
+// This is the highest installable version, or with PreferStable the highest installable version of the most stable stability
+// Returns an error if the options are invalid, see ComposerOptions.Validate
+//
+//	ex: versions '1.0.0', '1.1.0-beta1', constraint '^1.0@beta' would return '1.1.0-beta1', and '1.0.0' with PreferStable
+func MaxSatisfyingComposer(versions []versionTypes.Semver, c constraints.Constraint, options ComposerOptions) (versionTypes.Semver, error) {
+	if err := options.Validate(); err != nil {
+		return versionTypes.Semver{}, err
+	}
+
+	found := false
+	max := versionTypes.Semver{}
+	for _, version := range versions {
+		if !satisfiesComposer(version, c, options) {
+			continue
+		}
+		if !found {
+			max, found = version, true
+			continue
+		}
+
+		if options.PreferStable {
+			stability, maxStability := stabilityPrecedence(version.ComposerStability()), stabilityPrecedence(max.ComposerStability())
+			if stability != maxStability {
+				if stability > maxStability {
+					max = version
+				}
+				continue
+			}
+		}
+		if version.GT(max, false) {
+			max = version
+		}
+	}
+	if !found {
+		return versionTypes.Semver{}, ErrNoSatisfyingVersion
+	}
+	return max, nil
+}
+
+// Returns the least stability that is installable for the constraint, the least stable of the minimum-stability and the flag of the constraint
+func minimumStability(c constraints.Constraint, options ComposerOptions) string {
+	minimum := options.MinimumStability
+	if minimum == "" {
+		minimum = "stable"
+	}
+	if c.Stability != "" && stabilityPrecedence(c.Stability) < stabilityPrecedence(minimum) {
+		return c.Stability
+	}
+	return minimum
+}
+
+// Returns the precedence of a stability, where the stability is matched case insensitively as Composer does, e.g. rc := RC
+func stabilityPrecedence(stability string) int {
+	return slices.IndexFunc(versionTypes.ComposerStabilities, func(s string) bool {
+		return strings.EqualFold(s, stability)
+	})
+}
+
+// Evaluates a Composer dev branch, that is compared by its name rather than its numbers
+// Returns whether the version lies within the range, and false if neither the version is an unaliased branch nor the range is an exact dev-<branch> comparator
+//
+//	ex: version 'dev-main' and range '=dev-main' := true
+//	ex: version 'dev-main' and range '>=1.0.0' := false
+//	ex: version 'dev-main as 1.0.x-dev' and range '>=1.0.0' := not a branch evaluation, the alias is compared
+func evaluateBranch(v versionTypes.Semver, r constraints.Range) (bool, bool) {
+	if r.StartVersion.DevBranch != "" {
+		return r.StartOp == constraints.EQ && v.DevBranch == r.StartVersion.DevBranch, true
+	}
+	if v.IsUnaliasedBranch() {
+		return false, true
+	}
+	return false, false
+}
@@ -1,13 +1,17 @@
 package evaluator
 
 import (
-	"github.com/CodeClarityCE/utility-node-semver/utils"
+	"errors"
+	"sort"
+	"strings"
 
 	versionTypes "github.com/CodeClarityCE/utility-node-semver/versions"
 
 	constraints "github.com/CodeClarityCE/utility-node-semver/constraints"
 )
 
+var ErrNoSatisfyingVersion = errors.New("no version satisfies the constraint")
+
 // Takes a version and semver constraint
 // Returns true if the version satisfies the constraint and false otherwise
 //
@@ -20,143 +24,255 @@ import (
 //	ex: includePreReleases 'false' constraint '<= 5.0.0' and version '4.0.0-beta.2' would return false
 //	ex: includePreReleases 'true' constraint '<= 5.0.0' and version '4.0.0-beta.2' would return true
 func Satisfies(v versionTypes.Semver, c constraints.Constraint, includePreReleases bool) bool {
+	return satisfiesExpression(v, c.ExpressionTree(), includePreReleases)
+}
 
-	conjunctedConditions := []bool{}
-	joinIndex := -1
+// Evaluates the version against the expression tree of a constraint
+func satisfiesExpression(v versionTypes.Semver, e constraints.Expression, includePreReleases bool) bool {
 
-	for idx, cRange := range c.Ranges {
+	contained, preReleaseAllowed := evaluateExpression(v, e)
 
-		endversionEmpty := cRange.EndVersion == versionTypes.Semver{}
-		startversionEmpty := cRange.StartVersion == versionTypes.Semver{}
-		if !endversionEmpty && !startversionEmpty {
+	// According to the nodesemver spec:
+	//   "If a version has a prerelease tag (for example, 1.2.3-alpha.3) then it
+	//   will only be allowed to satisfy comparator sets if at least one comparator with the
+	//   same [major, minor, patch] tuple also has a prerelease tag."
+	//
+	// This behavior can be suppressed (treating all prerelease versions as if they were normal
+	// versions, for the purpose of range matching) by setting the includePreReleases
+	//
+	// The rule only restricts versions that have a prerelease tag. A version without one is matched by its
+	// position alone, even if the comparators have prereleases, e.g. 4.0.0 satisfies < 5.0.0-beta.2 as in nodesemver
+	if !includePreReleases && v.PreReleaseTag != "" {
+		return contained && preReleaseAllowed
+	}
 
-			startSatisified := false
-			endSatisified := false
+	return contained
+}
 
-			if cRange.StartOp == constraints.GT {
-				startSatisified = v.GT(cRange.StartVersion, false)
-			} else if cRange.StartOp == constraints.GE {
-				startSatisified = v.GE(cRange.StartVersion, false)
+// Evaluates a node of the expression tree
+// Returns whether the version lies within the node, and whether one of the comparators that the version
+// was matched against has a prerelease with the same [major, minor, patch] tuple
+//
+// A conjunction is a comparator set: the version must lie within all its operands, any of which can allow the prerelease.
+// A disjunction picks the operand that contains the version and allows its prerelease, if there is one.
+func evaluateExpression(v versionTypes.Semver, e constraints.Expression) (bool, bool) {
+	switch e.Type {
+	case constraints.AND_EXPRESSION:
+		preReleaseAllowed := false
+		for _, operand := range e.Operands {
+			contained, operandAllowsPreRelease := evaluateExpression(v, operand)
+			if !contained {
+				return false, false
 			}
-
-			if !startSatisified {
-				conjunctedConditions = append(conjunctedConditions, false)
-			} else {
-				if cRange.EndOp == constraints.LT {
-					endSatisified = v.LT(cRange.EndVersion, false)
-				} else if cRange.EndOp == constraints.LE {
-					endSatisified = v.LE(cRange.EndVersion, false)
-				}
-
-				// According to the nodesemver spec:
-				//   "If a version has a prerelease tag (for example, 1.2.3-alpha.3) then it
-				//   will only be allowed to satisfy comparator sets if at least one comparator with the
-				//   same [major, minor, patch] tuple also has a prerelease tag."
-				//
-				// This behavior can be suppressed (treating all prerelease versions as if they were normal
-				// versions, for the purpose of range matching) by setting the includePreReleases
-				if !includePreReleases && (v.PreReleaseTag != "" || cRange.StartVersion.PreReleaseTag != "" || cRange.EndVersion.PreReleaseTag != "") {
-
-					if cRange.StartVersion.PreReleaseTag == "" && cRange.EndVersion.PreReleaseTag == "" {
-						conjunctedConditions = append(conjunctedConditions, false)
-					} else {
-						cmpStart := v.EQ(cRange.StartVersion, true)
-						cmpEnd := v.EQ(cRange.EndVersion, true)
-
-						if !cmpStart && !cmpEnd {
-							conjunctedConditions = append(conjunctedConditions, false)
-						} else {
-							conjunctedConditions = append(conjunctedConditions, startSatisified && endSatisified)
-						}
-					}
-
-				} else {
-					conjunctedConditions = append(conjunctedConditions, startSatisified && endSatisified)
-				}
-
+			preReleaseAllowed = preReleaseAllowed || operandAllowsPreRelease
+		}
+		return true, preReleaseAllowed
+	case constraints.OR_EXPRESSION:
+		contained := false
+		for _, operand := range e.Operands {
+			operandContained, operandAllowsPreRelease := evaluateExpression(v, operand)
+			if operandContained && operandAllowsPreRelease {
+				return true, true
 			}
+			contained = contained || operandContained
+		}
+		return contained, false
+	default:
+		if contained, ok := evaluateBranch(v, e.Range); ok {
+			return contained, contained
+		}
+		i, contained := e.Range.IntervalSet().Find(v)
+		return contained, contained && (i.Lower.HasPreReleaseOf(v) || i.Upper.HasPreReleaseOf(v))
+	}
+}
 
-		} else {
-
-			res := false
-
-			if cRange.StartOp == constraints.GT {
-				res = v.GT(cRange.StartVersion, false)
-			} else if cRange.StartOp == constraints.GE {
-				res = v.GE(cRange.StartVersion, false)
-			} else if cRange.StartOp == constraints.EQ {
-				res = v.EQ(cRange.StartVersion, false)
-			} else if cRange.StartOp == constraints.LT {
-				res = v.LT(cRange.StartVersion, false)
-			} else if cRange.StartOp == constraints.LE {
-				res = v.LE(cRange.StartVersion, false)
-			}
+type Direction string
 
-			// According to the nodesemver spec:
-			//   "If a version has a prerelease tag (for example, 1.2.3-alpha.3) then it
-			//   will only be allowed to satisfy comparator sets if at least one comparator with the
-			//   same [major, minor, patch] tuple also has a prerelease tag."
-			//
-			// This behavior can be suppressed (treating all prerelease versions as if they were normal
-			// versions, for the purpose of range matching) by setting the includePreReleases
-			if !includePreReleases && (v.PreReleaseTag != "" || cRange.StartVersion.PreReleaseTag != "") {
-
-				if cRange.StartVersion.PreReleaseTag == "" {
-					res = false
-				} else {
-					cmpStart := v.EQ(cRange.StartVersion, true)
-
-					if !cmpStart {
-						res = false
-					}
-				}
+const (
+	ABOVE Direction = "above"
+	BELOW Direction = "below"
+)
 
-			}
+// Takes a version and semver constraint
+// Returns true if the version is greater than every version allowed by the constraint
+//
+//	ex: constraint '^1.2.0' and version '2.0.0' would return true
+//	ex: constraint '^1.2.0' and version '1.0.0' would return false
+//	ex: constraint '^1.2.0' and version '1.5.0' would return false
+func GTR(v versionTypes.Semver, c constraints.Constraint) bool {
+	return Outside(v, c, ABOVE)
+}
 
-			conjunctedConditions = append(conjunctedConditions, res)
+// Takes a version and semver constraint
+// Returns true if the version is lower than every version allowed by the constraint
+//
+//	ex: constraint '^1.2.0' and version '1.0.0' would return true
+//	ex: constraint '^1.2.0' and version '2.0.0' would return false
+//	ex: constraint '^1.2.0' and version '1.5.0' would return false
+func LTR(v versionTypes.Semver, c constraints.Constraint) bool {
+	return Outside(v, c, BELOW)
+}
 
+// Takes a version, semver constraint and direction
+// Returns true if the version lies outside of the constraint in the given direction,
+// i.e. it is greater (ABOVE) or lower (BELOW) than every version allowed by the constraint
+//
+//	ex: constraint '< 1.0.0 || >= 2.0.0' and version '1.5.0' would return false for both directions
+//
+// The position of a version does not depend on the prerelease rules: a version that lies between
+// the start and end of a range is never outside of it, even if it does not satisfy the range.
+//
+//	ex: constraint '^1.2.0' and version '1.5.0-beta' would return false for both directions
+//
+// As in nodesemver, a constraint that cannot be satisfied has every version outside of it.
+// An unknown direction always returns false.
+func Outside(v versionTypes.Semver, c constraints.Constraint, direction Direction) bool {
+	if direction != ABOVE && direction != BELOW {
+		return false
+	}
+	for _, i := range c.IntervalSet() {
+		if direction == ABOVE && !i.EndsBefore(v) {
+			return false
 		}
-
-		joinIndex++
-
-		if idx <= len(c.Join)-1 && c.Join[joinIndex] == constraints.DISJUNCTION {
-			if utils.ContainsOnly(conjunctedConditions, true) {
-				return true
-			}
-			conjunctedConditions = []bool{}
+		if direction == BELOW && !i.StartsAfter(v) {
+			return false
 		}
-
 	}
-
-	return utils.ContainsOnly(conjunctedConditions, true)
+	return true
 }
 
 // Evaluates the given constraints for each provided version and returns the hightest version that satisfies this constraint (if any)
+// Versions that do not satisfy the constraint are ignored, the zero version is returned if no version satisfies it
+//
+//	ex: versions '3.0.0', '1.0.0', '1.2.0' and constraint '^1.0.0' would return '1.2.0'
 func MaxSatisfying(versions []versionTypes.Semver, c constraints.Constraint, includePreReleases bool) versionTypes.Semver {
-	if len(versions) == 0 {
-		return versionTypes.Semver{}
-	}
-	max := versions[0]
+	max, _ := maxSatisfying(versions, c, includePreReleases)
+	return max
+}
+
+func maxSatisfying(versions []versionTypes.Semver, c constraints.Constraint, includePreReleases bool) (versionTypes.Semver, bool) {
+	expression := c.ExpressionTree()
+	found := false
+	max := versionTypes.Semver{}
 	for _, version := range versions {
-		if Satisfies(version, c, includePreReleases) {
-			if version.GT(max, false) {
-				max = version
-			}
+		if satisfiesExpression(version, expression, includePreReleases) && (!found || version.GT(max, false)) {
+			max, found = version, true
 		}
 	}
-	return max
+	return max, found
 }
 
-// Evaluates the given constraints for each provided version and returns the hightest version that satisfies this constraint (if any)
-// Equivalent to MaxSatisfying, but this function allows users to pass in versions as strings
+// Evaluates the given constraints for each provided version and returns the hightest version that satisfies this constraint
+// Equivalent to MaxSatisfying, but this function allows users to pass in versions as strings, which are parsed as NodeJS versions
+// Returns ErrNoSatisfyingVersion if no version satisfies the constraint
 func MaxSatisfyingStrings(versions []string, c constraints.Constraint, includePreReleases bool) (versionTypes.Semver, error) {
 	parsedVersions := []versionTypes.Semver{}
 	for _, versionString := range versions {
-		parsedVersion, err := versionTypes.ParseSemver(versionString)
+		parsedVersion, err := versionTypes.ParseSemverWithEcosystem(versionString, versionTypes.NODEJS_ECOSYSTEM)
 		if err != nil {
 			return versionTypes.Semver{}, err
 		}
 		parsedVersions = append(parsedVersions, parsedVersion)
 	}
-	return MaxSatisfying(parsedVersions, c, includePreReleases), nil
+	max, found := maxSatisfying(parsedVersions, c, includePreReleases)
+	if !found {
+		return versionTypes.Semver{}, ErrNoSatisfyingVersion
+	}
+	return max, nil
+}
+
+// Takes the list of all published versions of a package and a semver constraint
+// Returns a shorter constraint that is satisfied by the same published versions, or the given constraint if it is already shorter
+//
+//	ex: versions '1.0.0', '1.0.1', '1.0.2', '1.1.0', '1.2.0' and constraint '1.0.0 || 1.0.1 || 1.0.2 || 1.1.0' would return '<=1.1.0'
+//	ex: versions '0.9.0', '1.0.0', '1.0.1', '1.1.0', '1.2.0' and constraint '1.0.0 || 1.0.1 || 1.1.0' would return '>=1.0.0 <=1.1.0'
+//	ex: versions '1.0.0', '1.1.0' and constraint '1.0.0 || 1.1.0' would return '*'
+//
+// As in nodesemver, consecutive satisfying versions are grouped into a single range.
+// A prerelease version that does not satisfy the constraint splits a group, while the given constraint
+// is returned unchanged if a published prerelease version would not satisfy the simplified one.
+// Versions that were not published may satisfy the simplified constraint, even if they do not satisfy the given one.
+func Simplify(versions []versionTypes.Semver, c constraints.Constraint) constraints.Constraint {
+	sorted := make([]versionTypes.Semver, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LT(sorted[j], false)
+	})
+
+	// Groups of consecutive satisfying versions, an open group has no last version
+	type group struct {
+		first versionTypes.Semver
+		last  *versionTypes.Semver
+	}
+	groups := []group{}
+	var first, prev *versionTypes.Semver
+	for idx := range sorted {
+		if Satisfies(sorted[idx], c, false) {
+			prev = &sorted[idx]
+			if first == nil {
+				first = &sorted[idx]
+			}
+		} else {
+			if prev != nil {
+				groups = append(groups, group{first: *first, last: prev})
+			}
+			first, prev = nil, nil
+		}
+	}
+	if first != nil {
+		groups = append(groups, group{first: *first})
+	}
+
+	if len(groups) == 0 {
+		return c
+	}
+
+	simplified := constraints.Constraint{Ranges: []constraints.Range{}, Join: []constraints.JoinOp{}}
+	formatted := []string{}
+	for idx, g := range groups {
+		r := constraints.Range{}
+		startsAtLowest := g.first.EQ(sorted[0], false)
+		switch {
+		case g.last != nil && g.first.EQ(*g.last, false):
+			r.StartOp, r.StartVersion = constraints.EQ, g.first
+		case g.last == nil && startsAtLowest:
+			r.StartOp = constraints.GE
+		case g.last == nil:
+			r.StartOp, r.StartVersion = constraints.GE, g.first
+		case startsAtLowest:
+			r.StartOp, r.StartVersion = constraints.LE, *g.last
+		default:
+			r.StartOp, r.StartVersion = constraints.GE, g.first
+			r.EndOp, r.EndVersion = constraints.LE, *g.last
+		}
+
+		if idx > 0 {
+			simplified.Join = append(simplified.Join, constraints.DISJUNCTION)
+		}
+		simplified.Ranges = append(simplified.Ranges, r)
+		// As in nodesemver, a range without bounds allows any version
+		if g.last == nil && startsAtLowest {
+			formatted = append(formatted, "*")
+		} else {
+			formatted = append(formatted, r.String())
+		}
+	}
+	simplified.Original = strings.Join(formatted, " || ")
+
+	// A prerelease version only satisfies a range with a prerelease of the same tuple,
+	// so the simplified range might lose prereleases that the given constraint allows
+	for _, v := range sorted {
+		if Satisfies(v, simplified, false) != Satisfies(v, c, false) {
+			return c
+		}
+	}
+
+	original := c.Original
+	if original == "" {
+		original = c.String()
+	}
+	if len(simplified.Original) < len(original) {
+		return simplified
+	}
+	return c
 }
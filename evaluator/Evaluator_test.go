@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -28,6 +29,38 @@ type ConstraintToTestPreReleases struct {
 	IncludePreReleases bool
 }
 
+type OutsideToTest struct {
+	ConstraintString string
+	Version          versions.Semver
+	Direction        Direction
+	ExpectedResult   bool
+}
+
+type SimplifyToTest struct {
+	ConstraintString string
+	Versions         []string
+	ExpectedResult   string
+}
+
+type ComposerConstraintToTest struct {
+	ConstraintString string
+	Version          string
+	Options          ComposerOptions
+	ExpectedResult   bool
+}
+
+type ComposerOptionsToTest struct {
+	Options       ComposerOptions
+	ExpectedValid bool
+}
+
+type ComposerMaxSatisfyingToTest struct {
+	ConstraintString string
+	Versions         []string
+	Options          ComposerOptions
+	ExpectedResult   string
+}
+
 func validateConstraint(c ConstraintToTest) (bool, error) {
 
 	parsedConstraint, err := constraintTypes.ParseConstraint(c.ConstraintString)
@@ -516,6 +549,137 @@ func TestDisjunctionAndConjunctionConstraint(t *testing.T) {
 
 }
 
+func TestGroupedConstraint(t *testing.T) {
+
+	fmt.Printf("\n%s Testing parenthesized constraint expressions (... || ...) && ... evaluation %s\n", "----------------", "----------------")
+
+	constraintsToTest := []ConstraintToTest{
+		{
+			ConstraintString: "(>=1.0 <1.5) || (>=2.0 <2.3)",
+			Version:          versions.Semver{Major: 1, Minor: 4, Patch: 9},
+			ExpectedResult:   true,
+		},
+		{
+			ConstraintString: "(>=1.0 <1.5) || (>=2.0 <2.3)",
+			Version:          versions.Semver{Major: 1, Minor: 5, Patch: 0},
+			ExpectedResult:   false,
+		},
+		{
+			ConstraintString: "(>=1.0 <1.5) || (>=2.0 <2.3)",
+			Version:          versions.Semver{Major: 2, Minor: 2, Patch: 0},
+			ExpectedResult:   true,
+		},
+		{
+			ConstraintString: "(4.x || 5.x) && < 5.5.0",
+			Version:          versions.Semver{Major: 4, Minor: 2, Patch: 0},
+			ExpectedResult:   true,
+		},
+		{
+			ConstraintString: "(4.x || 5.x) && < 5.5.0",
+			Version:          versions.Semver{Major: 5, Minor: 6, Patch: 0},
+			ExpectedResult:   false,
+		},
+		{
+			// Without parentheses, && binds stronger than ||
+			ConstraintString: "4.x || 5.x && < 5.5.0",
+			Version:          versions.Semver{Major: 4, Minor: 6, Patch: 0},
+			ExpectedResult:   true,
+		},
+		{
+			ConstraintString: "4.x && (< 4.5.0 || > 4.8.0)",
+			Version:          versions.Semver{Major: 4, Minor: 6, Patch: 0},
+			ExpectedResult:   false,
+		},
+		{
+			ConstraintString: "4.x && (< 4.5.0 || > 4.8.0)",
+			Version:          versions.Semver{Major: 4, Minor: 9, Patch: 0},
+			ExpectedResult:   true,
+		},
+		{
+			// The prerelease is allowed by a comparator of the group, within the same comparator set
+			ConstraintString: "(>= 4.5.0-beta || 4.0.0) && < 5.0.0",
+			Version:          versions.Semver{Major: 4, Minor: 5, Patch: 0, PreReleaseTag: "rc.1"},
+			ExpectedResult:   true,
+		},
+		{
+			ConstraintString: "(>= 4.5.0-beta || 4.0.0) && < 5.0.0",
+			Version:          versions.Semver{Major: 4, Minor: 6, Patch: 0, PreReleaseTag: "rc.1"},
+			ExpectedResult:   false,
+		},
+		{
+			// Comparator sets are evaluated on their own, even if their ranges touch
+			ConstraintString: ">= 1.0.0 < 1.2.3-beta || >= 1.2.3-beta < 2.0.0",
+			Version:          versions.Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "rc.1"},
+			ExpectedResult:   true,
+		},
+	}
+
+	testConstraints(t, constraintsToTest)
+
+}
+
+func TestExclusionConstraint(t *testing.T) {
+
+	fmt.Printf("\n%s Testing exclusion constraint expressions (!=) evaluation %s\n", "----------------", "----------------")
+
+	constraintsToTest := []ConstraintToTest{
+		{
+			ConstraintString: ">=1.0.0 !=1.3.2 <2.0.0",
+			Version:          versions.Semver{Major: 1, Minor: 3, Patch: 2},
+			ExpectedResult:   false,
+		},
+		{
+			ConstraintString: ">=1.0.0 !=1.3.2 <2.0.0",
+			Version:          versions.Semver{Major: 1, Minor: 3, Patch: 3},
+			ExpectedResult:   true,
+		},
+		{
+			ConstraintString: ">=1.0.0 !=1.3.2 <2.0.0",
+			Version:          versions.Semver{Major: 2, Minor: 0, Patch: 0},
+			ExpectedResult:   false,
+		},
+		{
+			ConstraintString: "!=1.3.2",
+			Version:          versions.Semver{Major: 0, Minor: 0, Patch: 0},
+			ExpectedResult:   true,
+		},
+		{
+			ConstraintString: "!=1.3.2",
+			Version:          versions.Semver{Major: 1, Minor: 3, Patch: 2, MetaData: "build.5"},
+			ExpectedResult:   false,
+		},
+		{
+			ConstraintString: "!=1.3.2 && !=1.4.0",
+			Version:          versions.Semver{Major: 1, Minor: 4, Patch: 0},
+			ExpectedResult:   false,
+		},
+		{
+			ConstraintString: "^1.0.0 != 1.3.2 || 1.3.2",
+			Version:          versions.Semver{Major: 1, Minor: 3, Patch: 2},
+			ExpectedResult:   true,
+		},
+		{
+			// An exclusion does not allow prereleases of other versions
+			ConstraintString: "!=1.3.2",
+			Version:          versions.Semver{Major: 1, Minor: 3, Patch: 2, PreReleaseTag: "beta"},
+			ExpectedResult:   false,
+		},
+		{
+			ConstraintString: ">=1.3.2-alpha !=1.3.2-beta",
+			Version:          versions.Semver{Major: 1, Minor: 3, Patch: 2, PreReleaseTag: "beta"},
+			ExpectedResult:   false,
+		},
+		{
+			ConstraintString: ">=1.3.2-alpha !=1.3.2-beta",
+			Version:          versions.Semver{Major: 1, Minor: 3, Patch: 2, PreReleaseTag: "rc"},
+			ExpectedResult:   true,
+		},
+	}
+
+	testConstraints(t, constraintsToTest)
+
+}
+
 func TestXRangeConstraint(t *testing.T) {
 
 	fmt.Printf("\n%s Testing X range (5.x) evaluation %s\n", "----------------", "----------------")
@@ -1592,6 +1756,18 @@ func TestMaxSatisfyingEvaluation(t *testing.T) {
 			Versions:                     []string{"0.0.0", "2.5.0", "5.6.99", "5.7.19", "6.0.0"},
 			ExpectedMaxSatisfyingVersion: versions.Semver{Major: 5, Minor: 7, Patch: 19},
 		},
+
+		// Versions that do not satisfy the constraint are ignored, whatever their order
+		{
+			ConstraintString:             "^1.0.0",
+			Versions:                     []string{"3.0.0", "1.0.0"},
+			ExpectedMaxSatisfyingVersion: versions.Semver{Major: 1, Minor: 0, Patch: 0},
+		},
+		{
+			ConstraintString:             "^1.0.0",
+			Versions:                     []string{"3.0.0", "0.5.0"},
+			ExpectedMaxSatisfyingVersion: versions.Semver{},
+		},
 	}
 
 	testMaxSatisfying(t, constraintsToTest)
@@ -1709,10 +1885,27 @@ func TestIncludePreReleases(t *testing.T) {
 			IncludePreReleases: false,
 		},
 		// 4.0.0 satisfies < 5.0.0-beta.2?
-		//  yes, because 4.0.0 is strictly smaller thanb 5.0.0
+		//  yes, because 4.0.0 is strictly smaller than 5.0.0
+		//  the prerelease rule only restricts versions that have a prerelease tag, as in nodesemver
 		{
 			ConstraintString:   "< 5.0.0-beta.2",
 			Version:            versions.Semver{Major: 4, Minor: 0, Patch: 0},
+			ExpectedResult:     true,
+			IncludePreReleases: false,
+		},
+		// 5.5.0 satisfies >= 5.0.0-beta.2 < 6.0.0?
+		//  yes, a prerelease on a comparator does not restrict versions without prerelease
+		{
+			ConstraintString:   ">= 5.0.0-beta.2 < 6.0.0",
+			Version:            versions.Semver{Major: 5, Minor: 5, Patch: 0},
+			ExpectedResult:     true,
+			IncludePreReleases: false,
+		},
+		// 4.0.0-beta.1 satisfies < 5.0.0-beta.2?
+		//  no, because no comparator has a prerelease on the [4, 0, 0] tuple
+		{
+			ConstraintString:   "< 5.0.0-beta.2",
+			Version:            versions.Semver{Major: 4, Minor: 0, Patch: 0, PreReleaseTag: "beta.1"},
 			ExpectedResult:     false,
 			IncludePreReleases: false,
 		},
@@ -1863,6 +2056,290 @@ func TestIncludePreReleases(t *testing.T) {
 
 }
 
+func TestOutside(t *testing.T) {
+
+	fmt.Printf("\n%s Testing position of versions outside of constraints %s\n", "----------------", "----------------")
+
+	outsidesToTest := []OutsideToTest{
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 2, Minor: 0, Patch: 0}, Direction: ABOVE, ExpectedResult: true},
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 2, Minor: 0, Patch: 0, PreReleaseTag: "0"}, Direction: ABOVE, ExpectedResult: true},
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 2, Minor: 0, Patch: 0}, Direction: BELOW, ExpectedResult: false},
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 1, Minor: 0, Patch: 0}, Direction: BELOW, ExpectedResult: true},
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 1, Minor: 2, Patch: 0, PreReleaseTag: "beta"}, Direction: BELOW, ExpectedResult: true},
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 1, Minor: 0, Patch: 0}, Direction: ABOVE, ExpectedResult: false},
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 1, Minor: 5, Patch: 0}, Direction: ABOVE, ExpectedResult: false},
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 1, Minor: 5, Patch: 0}, Direction: BELOW, ExpectedResult: false},
+		// Not satisfying, but between the start and end of the range
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 1, Minor: 5, Patch: 0, PreReleaseTag: "beta"}, Direction: ABOVE, ExpectedResult: false},
+		{ConstraintString: "^1.2.0", Version: versions.Semver{Major: 1, Minor: 5, Patch: 0, PreReleaseTag: "beta"}, Direction: BELOW, ExpectedResult: false},
+		{ConstraintString: "< 1.0.0 || >= 2.0.0", Version: versions.Semver{Major: 1, Minor: 5, Patch: 0}, Direction: ABOVE, ExpectedResult: false},
+		{ConstraintString: "< 1.0.0 || >= 2.0.0", Version: versions.Semver{Major: 1, Minor: 5, Patch: 0}, Direction: BELOW, ExpectedResult: false},
+		{ConstraintString: "1.x || 2.x", Version: versions.Semver{Major: 3, Minor: 0, Patch: 0}, Direction: ABOVE, ExpectedResult: true},
+		{ConstraintString: "> 1.2.3", Version: versions.Semver{Major: 1, Minor: 2, Patch: 3}, Direction: BELOW, ExpectedResult: true},
+		{ConstraintString: "<= 1.2.3", Version: versions.Semver{Major: 1, Minor: 2, Patch: 3}, Direction: ABOVE, ExpectedResult: false},
+		{ConstraintString: "*", Version: versions.Semver{Major: 100, Minor: 0, Patch: 0}, Direction: ABOVE, ExpectedResult: false},
+	}
+
+	for _, outsideToTest := range outsidesToTest {
+		fmt.Printf("\nTesting if '%s' is %s '%s'\n", outsideToTest.Version.String(), outsideToTest.Direction, outsideToTest.ConstraintString)
+
+		parsedConstraint, err := constraintTypes.ParseConstraint(outsideToTest.ConstraintString)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", outsideToTest.ConstraintString, err)
+		}
+
+		outside := Outside(outsideToTest.Version, parsedConstraint, outsideToTest.Direction)
+		if outside != outsideToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected %t, but got: %t\n", outsideToTest.ExpectedResult, outside)
+			t.Errorf("✗ Failed. Expected %t, but got: %t\n", outsideToTest.ExpectedResult, outside)
+		} else {
+			fmt.Println("✓ Success")
+		}
+
+		// GTR and LTR are shorthands of Outside
+		if outsideToTest.Direction == ABOVE && GTR(outsideToTest.Version, parsedConstraint) != outside {
+			t.Errorf("✗ GTR and Outside disagree for '%s'\n", outsideToTest.ConstraintString)
+		}
+		if outsideToTest.Direction == BELOW && LTR(outsideToTest.Version, parsedConstraint) != outside {
+			t.Errorf("✗ LTR and Outside disagree for '%s'\n", outsideToTest.ConstraintString)
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+func TestSimplify(t *testing.T) {
+
+	fmt.Printf("\n%s Testing simplification of constraints %s\n", "----------------", "----------------")
+
+	published := []string{"0.9.0", "1.0.0", "1.0.1", "1.0.2", "1.1.0", "1.2.0", "2.0.0-beta.1", "2.0.0", "2.1.0"}
+
+	simplificationsToTest := []SimplifyToTest{
+		{ConstraintString: "1.0.0 || 1.0.1 || 1.0.2 || 1.1.0", Versions: published, ExpectedResult: ">=1.0.0 <=1.1.0"},
+		{ConstraintString: "0.9.0 || 1.0.0 || 1.0.1 || 1.0.2", Versions: published, ExpectedResult: "<=1.0.2"},
+		{ConstraintString: "1.2.0 || 2.0.0 || 2.1.0 || 2.1.0", Versions: []string{"1.1.0", "1.2.0", "2.0.0", "2.1.0"}, ExpectedResult: ">=1.2.0"},
+		// Unsatisfying prereleases split groups
+		{ConstraintString: "1.2.0 || 2.0.0 || 2.1.0", Versions: published, ExpectedResult: "=1.2.0 || >=2.0.0"},
+		{ConstraintString: "0.9.0 || 1.0.1 || 1.0.2 || 1.1.0 || 2.1.0", Versions: published, ExpectedResult: "=0.9.0 || >=1.0.1 <=1.1.0 || >=2.1.0"},
+		{ConstraintString: "0.9.0 || 1.x || 2.x", Versions: published, ExpectedResult: "<=1.2.0 || >=2.0.0"},
+		// Prereleases that satisfy the constraint would not satisfy the simplified one
+		{ConstraintString: "0.9.0 || 1.x || 2.0.0-beta.1 || 2.x", Versions: published, ExpectedResult: "0.9.0 || 1.x || 2.0.0-beta.1 || 2.x"},
+		// Every published version satisfies the constraint
+		{ConstraintString: "0.9.0 || 1.x || 2.x", Versions: []string{"0.9.0", "1.0.0", "1.2.0", "2.0.0", "2.1.0"}, ExpectedResult: "*"},
+		{ConstraintString: ">=1.0.0 <1.1.0 || 1.1.0", Versions: []string{"1.0.0", "1.0.1", "1.1.0"}, ExpectedResult: "*"},
+		// Already shorter than its simplification
+		{ConstraintString: "^1.0.0", Versions: published, ExpectedResult: "^1.0.0"},
+		{ConstraintString: "*", Versions: published, ExpectedResult: "*"},
+		// Nothing published satisfies the constraint
+		{ConstraintString: "3.0.0 || 3.0.1", Versions: published, ExpectedResult: "3.0.0 || 3.0.1"},
+	}
+
+	for _, simplificationToTest := range simplificationsToTest {
+		fmt.Printf("\nTesting simplification of '%s'\n", simplificationToTest.ConstraintString)
+
+		parsedConstraint, err := constraintTypes.ParseConstraint(simplificationToTest.ConstraintString)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", simplificationToTest.ConstraintString, err)
+		}
+		parsedVersions := []versions.Semver{}
+		for _, versionString := range simplificationToTest.Versions {
+			parsedVersion, err := versions.ParseSemver(versionString)
+			if err != nil {
+				t.Fatalf("✗ failed parsing of version: '%s'. %s\n", versionString, err)
+			}
+			parsedVersions = append(parsedVersions, parsedVersion)
+		}
+
+		simplified := Simplify(parsedVersions, parsedConstraint)
+		if simplified.Original != simplificationToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: '%s', but got: '%s'\n", simplificationToTest.ExpectedResult, simplified.Original)
+			t.Errorf("✗ Failed. Expected: '%s', but got: '%s'\n", simplificationToTest.ExpectedResult, simplified.Original)
+			continue
+		}
+
+		// The simplified constraint is satisfied by the same published versions
+		for _, v := range parsedVersions {
+			if Satisfies(v, simplified, false) != Satisfies(v, parsedConstraint, false) {
+				fmt.Printf("✗ Failed. '%s' and '%s' disagree on '%s'\n", simplified.Original, simplificationToTest.ConstraintString, v.String())
+				t.Errorf("✗ Failed. '%s' and '%s' disagree on '%s'\n", simplified.Original, simplificationToTest.ConstraintString, v.String())
+			}
+		}
+		fmt.Println("✓ Success")
+	}
+
+	fmt.Printf("\n")
+
+}
+
+func TestSatisfiesComposer(t *testing.T) {
+
+	fmt.Printf("\n%s Testing Composer constraint evaluation with stabilities %s\n", "----------------", "----------------")
+
+	constraintsToTest := []ComposerConstraintToTest{
+		{ConstraintString: "^2.0", Version: "2.1.0", ExpectedResult: true},
+		{ConstraintString: "^2.0", Version: "2.1.0-beta1", ExpectedResult: false},
+		{ConstraintString: "^2.0@beta", Version: "2.1.0-beta1", ExpectedResult: true},
+		{ConstraintString: "^2.0@beta", Version: "2.1.0-alpha1", ExpectedResult: false},
+		{ConstraintString: "^2.0@beta", Version: "3.0.0-beta1", ExpectedResult: false},
+		// The stability of a version in the constraint is implied
+		{ConstraintString: "^2.0-beta1", Version: "2.0.0-beta2", ExpectedResult: true},
+		// A patch release is stable
+		{ConstraintString: "^2.0", Version: "2.0.0-patch1", ExpectedResult: true},
+		{ConstraintString: "^2.0", Version: "2.1.0-RC1", Options: ComposerOptions{MinimumStability: "RC"}, ExpectedResult: true},
+		{ConstraintString: "^2.0", Version: "2.1.0-alpha1", Options: ComposerOptions{MinimumStability: "beta"}, ExpectedResult: false},
+		{ConstraintString: "^2.0", Version: "2.1.x-dev", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: true},
+		// A wildcard with the dev modifier only allows the head of its branch
+		{ConstraintString: "1.0.x-dev", Version: "1.0.x-dev", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: true},
+		{ConstraintString: "1.0.x-dev", Version: "1.0.5", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: false},
+		{ConstraintString: "1.0.x-dev", Version: "1.0.5-dev", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: false},
+		{ConstraintString: "1.0.x-dev", Version: "1.1.x-dev", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: false},
+		{ConstraintString: "!=1.0.x-dev", Version: "1.0.x-dev", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: false},
+		{ConstraintString: "!=1.0.x-dev", Version: "1.0.5", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: true},
+		{ConstraintString: "1.0.0-dev", Version: "1.0.0-dev", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: true},
+		// A flag never raises the minimum-stability
+		{ConstraintString: "^2.0@stable", Version: "2.1.0-alpha1", Options: ComposerOptions{MinimumStability: "alpha"}, ExpectedResult: true},
+		{ConstraintString: "^2.0@RC", Version: "2.1.0-rc1", ExpectedResult: true},
+		// The stability of a bound is expanded as the stability of a version, e.g. rc := RC and b := beta
+		{ConstraintString: ">=1.0.0-rc1", Version: "1.0.0-alpha1", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: false},
+		{ConstraintString: ">=1.0.0-rc1", Version: "1.0.0-RC2", ExpectedResult: true},
+		{ConstraintString: ">=1.0.0-b1", Version: "1.0.0-dev", Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: false},
+		{ConstraintString: ">=1.0.0-b1", Version: "1.0.0-beta2", ExpectedResult: true},
+		{ConstraintString: "<1.0.0-pl2", Version: "1.0.0-p1", ExpectedResult: true},
+		// The revision of a four-part version is compared
+		{ConstraintString: ">=1.2.3.4", Version: "1.2.3.4", ExpectedResult: true},
+		{ConstraintString: ">=1.2.3.4", Version: "1.2.3.3", ExpectedResult: false},
+		{ConstraintString: "~1.2.3.4", Version: "1.2.3.9", ExpectedResult: true},
+		{ConstraintString: "~1.2.3.4", Version: "1.2.4.0", ExpectedResult: false},
+	}
+
+	for _, constraintToTest := range constraintsToTest {
+		fmt.Printf("\nTesting Composer constraint evaluation. Does '%s' satisfy: '%s' with minimum-stability '%s'\n", constraintToTest.Version, constraintToTest.ConstraintString, constraintToTest.Options.MinimumStability)
+
+		parsedConstraint, err := constraintTypes.ParseConstraintWithEcosystem(constraintToTest.ConstraintString, "composer")
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", constraintToTest.ConstraintString, err)
+		}
+		parsedVersion, err := versions.ParseSemverWithEcosystem(constraintToTest.Version, "composer")
+		if err != nil {
+			t.Fatalf("✗ failed parsing of version: '%s'. %s\n", constraintToTest.Version, err)
+		}
+
+		satisfies, err := SatisfiesComposer(parsedVersion, parsedConstraint, constraintToTest.Options)
+		if err != nil {
+			t.Fatalf("✗ failed evaluation with minimum-stability: '%s'. %s\n", constraintToTest.Options.MinimumStability, err)
+		}
+		if satisfies != constraintToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: %t, but got: %t\n", constraintToTest.ExpectedResult, satisfies)
+			t.Errorf("✗ Failed. Expected: %t, but got: %t\n", constraintToTest.ExpectedResult, satisfies)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+func TestMaxSatisfyingComposer(t *testing.T) {
+
+	fmt.Printf("\n%s Testing Composer max satisfying evaluation with stabilities %s\n", "----------------", "----------------")
+
+	published := []string{"1.0.0", "1.1.0", "1.2.0-beta1", "1.2.0-RC1", "1.3.x-dev", "2.0.0-alpha1"}
+
+	maxSatisfyingToTest := []ComposerMaxSatisfyingToTest{
+		{ConstraintString: "^1.0", Versions: published, ExpectedResult: "1.1.0"},
+		{ConstraintString: "^1.0@beta", Versions: published, ExpectedResult: "1.2.0-RC1"},
+		{ConstraintString: "^1.0", Versions: published, Options: ComposerOptions{MinimumStability: "dev"}, ExpectedResult: "1.3.x-dev"},
+		// The most stable versions are preferred, then the highest of them
+		{ConstraintString: "^1.0", Versions: published, Options: ComposerOptions{MinimumStability: "dev", PreferStable: true}, ExpectedResult: "1.1.0"},
+		{ConstraintString: "^1.2@beta", Versions: published, Options: ComposerOptions{PreferStable: true}, ExpectedResult: "1.2.0-RC1"},
+		{ConstraintString: "^2.0", Versions: published, Options: ComposerOptions{MinimumStability: "alpha", PreferStable: true}, ExpectedResult: "2.0.0-alpha1"},
+		// Nothing is installable
+		{ConstraintString: "^2.0", Versions: published, ExpectedResult: ""},
+	}
+
+	for _, maxToTest := range maxSatisfyingToTest {
+		fmt.Printf("\nTesting Composer max satisfying evaluation for '%s' with %+v\n", maxToTest.ConstraintString, maxToTest.Options)
+
+		parsedConstraint, err := constraintTypes.ParseConstraintWithEcosystem(maxToTest.ConstraintString, "composer")
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", maxToTest.ConstraintString, err)
+		}
+		parsedVersions := []versions.Semver{}
+		for _, versionString := range maxToTest.Versions {
+			parsedVersion, err := versions.ParseSemverWithEcosystem(versionString, "composer")
+			if err != nil {
+				t.Fatalf("✗ failed parsing of version: '%s'. %s\n", versionString, err)
+			}
+			parsedVersions = append(parsedVersions, parsedVersion)
+		}
+
+		max, err := MaxSatisfyingComposer(parsedVersions, parsedConstraint, maxToTest.Options)
+		if err != nil && !errors.Is(err, ErrNoSatisfyingVersion) {
+			t.Fatalf("✗ failed evaluation with %+v. %s\n", maxToTest.Options, err)
+		}
+		result := ""
+		if err == nil {
+			result = max.Raw
+		}
+		if result != maxToTest.ExpectedResult {
+			fmt.Printf("✗ Failed. Expected: '%s', but got: '%s'\n", maxToTest.ExpectedResult, result)
+			t.Errorf("✗ Failed. Expected: '%s', but got: '%s'\n", maxToTest.ExpectedResult, result)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
+func TestComposerOptionsValidation(t *testing.T) {
+
+	fmt.Printf("\n%s Testing Composer options validation %s\n", "----------------", "----------------")
+
+	optionsToTest := []ComposerOptionsToTest{
+		{Options: ComposerOptions{}, ExpectedValid: true},
+		{Options: ComposerOptions{MinimumStability: "dev"}, ExpectedValid: true},
+		{Options: ComposerOptions{MinimumStability: "rc"}, ExpectedValid: true},
+		{Options: ComposerOptions{MinimumStability: "Stable", PreferStable: true}, ExpectedValid: true},
+		{Options: ComposerOptions{MinimumStability: "stabel"}, ExpectedValid: false},
+		// patch is the stability of a version, not a minimum-stability
+		{Options: ComposerOptions{MinimumStability: "patch"}, ExpectedValid: false},
+	}
+
+	parsedConstraint, err := constraintTypes.ParseConstraintWithEcosystem("^1.0", "composer")
+	if err != nil {
+		t.Fatalf("✗ failed parsing of constraint: '^1.0'. %s\n", err)
+	}
+	parsedVersion, err := versions.ParseSemverWithEcosystem("1.0.0", "composer")
+	if err != nil {
+		t.Fatalf("✗ failed parsing of version: '1.0.0'. %s\n", err)
+	}
+
+	for _, optionsToTest := range optionsToTest {
+		fmt.Printf("\nTesting Composer options validation of %+v\n", optionsToTest.Options)
+
+		_, satisfiesErr := SatisfiesComposer(parsedVersion, parsedConstraint, optionsToTest.Options)
+		_, maxErr := MaxSatisfyingComposer([]versions.Semver{parsedVersion}, parsedConstraint, optionsToTest.Options)
+		correct := true
+		for _, err := range []error{optionsToTest.Options.Validate(), satisfiesErr, maxErr} {
+			if valid := err == nil; valid != optionsToTest.ExpectedValid || (!valid && !errors.Is(err, ErrUnknownStability)) {
+				fmt.Printf("✗ Failed. Expected valid: %t, but got: %v\n", optionsToTest.ExpectedValid, err)
+				t.Errorf("✗ Failed. Expected valid: %t, but got: %v\n", optionsToTest.ExpectedValid, err)
+				correct = false
+			}
+		}
+		if correct {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
+
 func testConstraintsPreReleases(t *testing.T, constraintsToTest []ConstraintToTestPreReleases) {
 	for _, constraintToTest := range constraintsToTest {
 		fmt.Printf("\nTesting constraint evaluation. Does '%s' satisfy: '%s'\n", constraintToTest.Version.String(), constraintToTest.ConstraintString)
@@ -1912,6 +2389,10 @@ func testMaxSatisfying(t *testing.T, maxSatisfyíngConstraintsToTest []MaxSatisf
 
 			max, err := MaxSatisfyingStrings(maxSatisfyíngConstraintToTest.Versions, parsedConstraint, true)
 
+			// No version satisfies the constraint, as the zero version is expected
+			if errors.Is(err, ErrNoSatisfyingVersion) {
+				err = nil
+			}
 			if err != nil {
 				fmt.Printf("✗ failed version parsing of included versions: '%s'. %s\n", maxSatisfyíngConstraintToTest.ConstraintString, err)
 				t.Errorf("✗ failed version parsing of included versions: '%s'. %s\n", maxSatisfyíngConstraintToTest.ConstraintString, err)
@@ -1940,6 +2421,18 @@ func testMaxSatisfying(t *testing.T, maxSatisfyíngConstraintsToTest []MaxSatisf
 				correct = false
 			}
 
+			// The versions as strings give the same version as the parsed versions
+			parsedVersions := []versions.Semver{}
+			for _, versionString := range maxSatisfyíngConstraintToTest.Versions {
+				parsedVersion, _ := versions.ParseSemver(versionString)
+				parsedVersions = append(parsedVersions, parsedVersion)
+			}
+			if parsedMax := MaxSatisfying(parsedVersions, parsedConstraint, true); !parsedMax.EQ(max, true) {
+				fmt.Printf("✗ MaxSatisfying and MaxSatisfyingStrings disagree: '%s' and '%s'\n", parsedMax.String(), max.String())
+				t.Errorf("✗ MaxSatisfying and MaxSatisfyingStrings disagree: '%s' and '%s'\n", parsedMax.String(), max.String())
+				correct = false
+			}
+
 			if correct {
 				fmt.Println("✓ Success")
 			}
@@ -0,0 +1,203 @@
+package lint
+
+import (
+	"fmt"
+
+	constraints "github.com/CodeClarityCE/utility-node-semver/constraints"
+	evaluator "github.com/CodeClarityCE/utility-node-semver/evaluator"
+	versions "github.com/CodeClarityCE/utility-node-semver/versions"
+)
+
+type Severity string
+
+const (
+	SEVERITY_INFO    Severity = "info"
+	SEVERITY_WARNING Severity = "warning"
+	SEVERITY_ERROR   Severity = "error"
+)
+
+// A RuleID identifies the rule that produced a Finding, it is stable and can be used to suppress findings
+type RuleID string
+
+const (
+	// The constraint cannot be satisfied by any version, e.g. >=2.0.0 <1.0.0
+	RULE_UNSATISFIABLE RuleID = "SV001"
+	// A comparator set of the constraint cannot be satisfied by any version, e.g. 1.x || >=2.0.0 <1.0.0
+	RULE_UNSATISFIABLE_SET RuleID = "SV002"
+	// A comparator set only allows versions that are already allowed by the others, e.g. ^1.2.3 || ^1.2.4
+	RULE_REDUNDANT_SET RuleID = "SV003"
+	// A comparator set allows any version, e.g. *
+	RULE_ANY_VERSION RuleID = "SV004"
+	// An exclusive upper bound on a release does not match its prereleases, e.g. <2.0.0 and 2.0.0-rc.1
+	RULE_EXCLUDED_PRERELEASES RuleID = "SV005"
+)
+
+// A Finding is a range that is legal, but most likely not what was meant
+type Finding struct {
+	Rule     RuleID
+	Severity Severity
+	// The comparator set the finding refers to, in desugared constraint syntax
+	Range   string
+	Message string
+}
+
+// Options configure LintWithOptions
+type Options struct {
+	// Constraints of production dependencies are held to a higher standard, e.g. RULE_ANY_VERSION is an error
+	Production bool
+}
+
+// Takes a parsed semver constraint
+// Returns the findings of all rules, in the order of the comparator sets of the constraint
+//
+//	ex: constraint '^1.2.3 || ^1.2.4' would return a RULE_REDUNDANT_SET finding for '>=1.2.4 <2.0.0-0'
+func Lint(c constraints.Constraint) []Finding {
+	return LintWithOptions(c, Options{})
+}
+
+// Equivalent to Lint, but the severity of the findings depends on the given options
+func LintWithOptions(c constraints.Constraint, options Options) []Finding {
+	findings := []Finding{}
+
+	if c.IntervalSet().IsEmpty() {
+		return append(findings, Finding{
+			Rule:     RULE_UNSATISFIABLE,
+			Severity: SEVERITY_ERROR,
+			Range:    c.String(),
+			Message:  "constraint cannot be satisfied by any version",
+		})
+	}
+
+	sets := comparatorSets(c)
+	redundant := make([]bool, len(sets))
+
+	// Later sets are checked first, such that of two identical sets the later one is reported
+	for idx := len(sets) - 1; idx >= 0; idx-- {
+		set := sets[idx]
+		if set.IntervalSet().IsEmpty() {
+			redundant[idx] = true
+			continue
+		}
+		others := []constraints.Range{}
+		othersJoin := []constraints.JoinOp{}
+		for otherIdx, other := range sets {
+			if otherIdx == idx || redundant[otherIdx] {
+				continue
+			}
+			if len(others) > 0 {
+				othersJoin = append(othersJoin, constraints.DISJUNCTION)
+			}
+			others = append(others, other.Ranges...)
+			othersJoin = append(othersJoin, other.Join...)
+		}
+		if len(others) > 0 && constraints.IsSubset(set, constraints.Constraint{Ranges: others, Join: othersJoin}, false) {
+			redundant[idx] = true
+		}
+	}
+
+	for idx, set := range sets {
+		formatted := set.String()
+
+		if set.IntervalSet().IsEmpty() {
+			findings = append(findings, Finding{
+				Rule:     RULE_UNSATISFIABLE_SET,
+				Severity: SEVERITY_WARNING,
+				Range:    formatted,
+				Message:  fmt.Sprintf("'%s' cannot be satisfied by any version", formatted),
+			})
+			continue
+		}
+
+		if redundant[idx] {
+			findings = append(findings, Finding{
+				Rule:     RULE_REDUNDANT_SET,
+				Severity: SEVERITY_WARNING,
+				Range:    formatted,
+				Message:  fmt.Sprintf("'%s' only allows versions that the rest of the constraint already allows", formatted),
+			})
+		}
+
+		if allowsAnyVersion(set) {
+			severity := SEVERITY_WARNING
+			if options.Production {
+				severity = SEVERITY_ERROR
+			}
+			findings = append(findings, Finding{
+				Rule:     RULE_ANY_VERSION,
+				Severity: severity,
+				Range:    formatted,
+				Message:  fmt.Sprintf("'%s' allows any version, including future major versions", formatted),
+			})
+		}
+
+		for _, upper := range excludedPreReleaseBounds(set) {
+			findings = append(findings, Finding{
+				Rule:     RULE_EXCLUDED_PRERELEASES,
+				Severity: SEVERITY_INFO,
+				Range:    formatted,
+				Message: fmt.Sprintf("'<%s' does not match prereleases of %s, e.g. %s-rc.1, unless prereleases are included, use '<%s-0' to exclude them explicitly",
+					upper.String(), upper.String(), upper.String(), upper.String()),
+			})
+		}
+	}
+
+	return findings
+}
+
+// Returns the comparator sets of the constraint, each being a conjunction of ranges
+//
+//	ex: 1.x || >=2.0.0 <2.5.0 := [1.x] [>=2.0.0, <2.5.0]
+func comparatorSets(c constraints.Constraint) []constraints.Constraint {
+	sets := []constraints.Constraint{}
+	current := constraints.Constraint{Ranges: []constraints.Range{}, Join: []constraints.JoinOp{}}
+
+	for idx, r := range c.Ranges {
+		if len(current.Ranges) > 0 {
+			current.Join = append(current.Join, constraints.CONJUNCTON)
+		}
+		current.Ranges = append(current.Ranges, r)
+
+		if idx >= len(c.Join) || c.Join[idx] == constraints.DISJUNCTION {
+			sets = append(sets, current)
+			current = constraints.Constraint{Ranges: []constraints.Range{}, Join: []constraints.JoinOp{}}
+		}
+	}
+
+	return sets
+}
+
+// Returns true if the comparator set allows every release, from 0.0.0 upwards
+func allowsAnyVersion(set constraints.Constraint) bool {
+	for _, i := range set.IntervalSet() {
+		if i.Upper.Type == constraints.UNBOUNDED && i.Contains(versions.Semver{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the versions of the exclusive upper bounds of the comparator set, whose prereleases are only allowed
+// when prereleases are included
+//
+//	ex: >=1.0.0 <2.0.0 := [2.0.0], as 2.0.0-rc.1 satisfies it only with includePreReleases
+//	ex: >=2.0.0-alpha <2.0.0 := [], as the prerelease comparator allows prereleases of 2.0.0
+func excludedPreReleaseBounds(set constraints.Constraint) []versions.Semver {
+	bounds := []versions.Semver{}
+	for _, r := range set.Ranges {
+		upper := r.StartVersion
+		if r.EndOp == constraints.LT {
+			upper = r.EndVersion
+		} else if r.StartOp != constraints.LT {
+			continue
+		}
+		if upper.PreReleaseTag != "" {
+			continue
+		}
+
+		preRelease := versions.Semver{Major: upper.Major, Minor: upper.Minor, Patch: upper.Patch, PreReleaseTag: "0"}
+		if evaluator.Satisfies(preRelease, set, true) && !evaluator.Satisfies(preRelease, set, false) {
+			bounds = append(bounds, upper)
+		}
+	}
+	return bounds
+}
@@ -0,0 +1,104 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	constraints "github.com/CodeClarityCE/utility-node-semver/constraints"
+)
+
+type FindingToTest struct {
+	Rule     RuleID
+	Severity Severity
+	Range    string
+}
+
+type LintToTest struct {
+	ConstraintString string
+	Production       bool
+	Ecosystem        string
+	ExpectedFindings []FindingToTest
+}
+
+func TestLint(t *testing.T) {
+
+	fmt.Printf("\n%s Testing constraint linter %s\n", "----------------", "----------------")
+
+	lintsToTest := []LintToTest{
+		{ConstraintString: "^1.2.3", ExpectedFindings: []FindingToTest{}},
+		{ConstraintString: "1.x || 2.x", ExpectedFindings: []FindingToTest{}},
+		{ConstraintString: ">=2.0.0-alpha <2.0.0", ExpectedFindings: []FindingToTest{}},
+		{
+			ConstraintString: ">=2.0.0 <1.0.0",
+			ExpectedFindings: []FindingToTest{{Rule: RULE_UNSATISFIABLE, Severity: SEVERITY_ERROR, Range: ">=2.0.0 <1.0.0"}},
+		},
+		{
+			ConstraintString: "1.x || >=2.0.0 <1.0.0",
+			ExpectedFindings: []FindingToTest{{Rule: RULE_UNSATISFIABLE_SET, Severity: SEVERITY_WARNING, Range: ">=2.0.0 <1.0.0"}},
+		},
+		{
+			ConstraintString: "^1.2.3 || ^1.2.4",
+			ExpectedFindings: []FindingToTest{{Rule: RULE_REDUNDANT_SET, Severity: SEVERITY_WARNING, Range: ">=1.2.4 <2.0.0-0"}},
+		},
+		// Of two identical sets, only the later one is redundant
+		{
+			ConstraintString: "~1.2.0 || ~1.2.0",
+			ExpectedFindings: []FindingToTest{{Rule: RULE_REDUNDANT_SET, Severity: SEVERITY_WARNING, Range: ">=1.2.0 <1.3.0-0"}},
+		},
+		// A set allowing prereleases the others do not allow is not redundant
+		{
+			ConstraintString: "^1.0.0 || >=1.5.0-beta <=1.6.0",
+			ExpectedFindings: []FindingToTest{},
+		},
+		// A branch is not a numbered version, so >=1.0 does not make it redundant
+		{
+			ConstraintString: "dev-main || >=1.0",
+			Ecosystem:        "composer",
+			ExpectedFindings: []FindingToTest{},
+		},
+		{
+			ConstraintString: "*",
+			ExpectedFindings: []FindingToTest{{Rule: RULE_ANY_VERSION, Severity: SEVERITY_WARNING, Range: ">=0.0.0"}},
+		},
+		{
+			ConstraintString: "*",
+			Production:       true,
+			ExpectedFindings: []FindingToTest{{Rule: RULE_ANY_VERSION, Severity: SEVERITY_ERROR, Range: ">=0.0.0"}},
+		},
+		{
+			ConstraintString: "<2.0.0",
+			ExpectedFindings: []FindingToTest{{Rule: RULE_EXCLUDED_PRERELEASES, Severity: SEVERITY_INFO, Range: "<2.0.0"}},
+		},
+		{
+			ConstraintString: ">=1.0.0 <2.0.0 || ^3.0.0",
+			ExpectedFindings: []FindingToTest{{Rule: RULE_EXCLUDED_PRERELEASES, Severity: SEVERITY_INFO, Range: ">=1.0.0 <2.0.0"}},
+		},
+	}
+
+	for _, lintToTest := range lintsToTest {
+		fmt.Printf("\nTesting lint of '%s'\n", lintToTest.ConstraintString)
+
+		c, err := constraints.ParseConstraintWithEcosystem(lintToTest.ConstraintString, lintToTest.Ecosystem)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of constraint: '%s'. %s\n", lintToTest.ConstraintString, err)
+		}
+
+		findings := LintWithOptions(c, Options{Production: lintToTest.Production})
+
+		got := []FindingToTest{}
+		for _, finding := range findings {
+			got = append(got, FindingToTest{Rule: finding.Rule, Severity: finding.Severity, Range: finding.Range})
+		}
+
+		if !slices.Equal(got, lintToTest.ExpectedFindings) {
+			fmt.Printf("✗ Failed. Expected: %v, but got: %v\n", lintToTest.ExpectedFindings, findings)
+			t.Errorf("✗ Failed. Expected: %v, but got: %v\n", lintToTest.ExpectedFindings, findings)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+
+}
@@ -8,36 +8,51 @@ import (
 	versions "github.com/CodeClarityCE/utility-node-semver/versions"
 )
 
-// EcosystemType represents different package ecosystem types
-type EcosystemType string
-
-const (
-	// NodeJS represents Node.js/npm ecosystem with Node.js semver rules
-	NodeJS EcosystemType = "nodejs"
-	// Composer represents PHP/Composer ecosystem with Composer semver rules
-	Composer EcosystemType = "composer"
-)
-
 // Parses a given semver constraint string into a constraint object for specified ecosystem
+// The constraint is parsed by the registered ecosystem, see RegisterEcosystem
 func ParseConstraintWithEcosystem(constraintString string, ecosystem EcosystemType) (constraints.Constraint, error) {
-	return constraints.ParseConstraintWithEcosystem(constraintString, string(ecosystem))
+	return LookupEcosystem(ecosystem).ParseConstraint(constraintString)
 }
 
 // Parses a semver string into a semver object for specified ecosystem
+// The version is parsed by the registered ecosystem, see RegisterEcosystem
 func ParseSemverWithEcosystem(versionLiteral string, ecosystem EcosystemType) (versions.Semver, error) {
-	return versions.ParseSemverWithEcosystem(versionLiteral, string(ecosystem))
+	return LookupEcosystem(ecosystem).ParseVersion(versionLiteral)
 }
 
 // Parses a given node semver constraint string into a constraint object
 // DEPRECATED: Use ParseConstraintWithEcosystem for new code. Defaults to NodeJS boilerplates.
 func ParseConstraint(constraintString string) (constraints.Constraint, error) {
-	return constraints.ParseConstraintWithEcosystem(constraintString, string(NodeJS))
+	return ParseConstraintWithEcosystem(constraintString, NodeJS)
+}
+
+// Parses a given node semver constraint string into a constraint object
+// Warnings and errors found while parsing are reported to the diagnostics sink of the options, the parser never logs on its own
+func ParseConstraintWithOptions(constraintString string, options constraints.ParseOptions) (constraints.Constraint, error) {
+	return constraints.ParseConstraintWithOptions(constraintString, options)
+}
+
+// Parses a semver string into a semver object with the given options
+// In loose mode the rewrites done to the literal before parsing are returned
+//
+//	ex: '=v1.2.3' would return '1.2.3' in loose mode
+func ParseSemverWithOptions(versionLiteral string, options versions.ParseOptions) (versions.Semver, []versions.Rewrite, error) {
+	return versions.ParseSemverWithOptions(versionLiteral, options)
 }
 
 // Parses a semver string into a semver object
 // DEPRECATED: Use ParseSemverWithEcosystem for new code. Defaults to NodeJS boilerplates.
 func ParseSemver(versionLiteral string) (versions.Semver, error) {
-	return versions.ParseSemverWithEcosystem(versionLiteral, string(NodeJS))
+	return ParseSemverWithEcosystem(versionLiteral, NodeJS)
+}
+
+// Takes an arbitrary string, e.g. a container label or a binary banner
+// Returns the version found in the string, and false if the string does not contain a number
+//
+//	ex: 'release-1.4.7-final' would return '1.4.7'
+//	ex: 'OpenSSL 3.0.2 15 Mar 2022' would return '3.0.2', and '2022.0.0' with the RTL option
+func Coerce(s string, opts versions.CoerceOptions) (versions.Semver, bool) {
+	return versions.Coerce(s, opts)
 }
 
 // Takes a version and semver constraint
@@ -55,15 +70,107 @@ func Satisfies(v versions.Semver, c constraints.Constraint, includePreReleases b
 	return evaluator.Satisfies(v, c, includePreReleases)
 }
 
+// Takes a Composer version and a Composer constraint
+// Returns true if the version satisfies the constraint and its stability is at least the minimum-stability, lowered by the stability flag of the constraint
+//
+//	ex: minimum-stability 'stable', constraint '^2.0' and version '2.1.0-beta1' would return false
+//	ex: minimum-stability 'stable', constraint '^2.0@beta' and version '2.1.0-beta1' would return true
+//
+// An unknown minimum-stability, e.g. 'stabel', returns an error wrapping evaluator.ErrUnknownStability
+func SatisfiesComposer(v versions.Semver, c constraints.Constraint, options evaluator.ComposerOptions) (bool, error) {
+	return evaluator.SatisfiesComposer(v, c, options)
+}
+
+// Takes two semver constraints
+// Returns true if any version could satisfy both constraints and false otherwise
+//
+//	ex: constraints '^1.2.0' and '>= 1.4.0 < 3.0.0' would return true
+//	ex: constraints '^1.2.0' and '^2.0.0' would return false
+//
+// includePreReleases behaves as in Satisfies
+func Intersects(c1 constraints.Constraint, c2 constraints.Constraint, includePreReleases bool) bool {
+	return constraints.Intersects(c1, c2, includePreReleases)
+}
+
+// Takes two semver constraints
+// Returns true if both constraints allow exactly the same versions and false otherwise
+//
+//	ex: constraints '^1.0.0' and '>= 1.0.0 < 2.0.0-0' would return true
+//	ex: constraints '^1.0.0' and '~1.0.0' would return false
+func Equivalent(c1 constraints.Constraint, c2 constraints.Constraint) bool {
+	return constraints.Equivalent(c1, c2)
+}
+
+// Takes a semver constraint
+// Returns the lowest version that satisfies the constraint, and false if no version can satisfy it
+//
+//	ex: constraint '>= 1.2.3' would return '1.2.3'
+//	ex: constraint '> 1.2.3' would return '1.2.4'
+//	ex: constraint '> 1.2.3-beta' would return '1.2.3-beta.0'
+func MinVersion(c constraints.Constraint) (versions.Semver, bool) {
+	return constraints.MinVersion(c)
+}
+
 // Evaluates the given constraints for each provided version and returns the hightest version that satisfies this constraint (if any)
+// Versions that do not satisfy the constraint are ignored, the zero version is returned if no version satisfies it
+//
+//	ex: versions '3.0.0', '1.0.0' and constraint '^1.0.0' would return '1.0.0'
 func MaxSatisfying(versions []versions.Semver, c constraints.Constraint, includePreReleases bool) versions.Semver {
 	return evaluator.MaxSatisfying(versions, c, includePreReleases)
 }
 
-// Evaluates the given constraints for each provided version and returns the hightest version that satisfies this constraint (if any)
+// Takes Composer versions and a Composer constraint
+// Returns the version that composer update would pick, and evaluator.ErrNoSatisfyingVersion if no version is installable
+//
+//	ex: versions '1.0.0', '1.1.0-beta1', constraint '^1.0@beta' would return '1.1.0-beta1', and '1.0.0' with PreferStable
+//
+// An unknown minimum-stability, e.g. 'stabel', returns an error wrapping evaluator.ErrUnknownStability
+func MaxSatisfyingComposer(versions []versions.Semver, c constraints.Constraint, options evaluator.ComposerOptions) (versions.Semver, error) {
+	return evaluator.MaxSatisfyingComposer(versions, c, options)
+}
+
+// Evaluates the given constraints for each provided version and returns the hightest version that satisfies this constraint
 // Equivalent to MaxSatisfying, but this function allows users to pass in versions as strings
-func MaxSatisfyingStrings(versions []string, c constraints.Constraint, includePreReleases bool) (versions.Semver, error) {
-	return evaluator.MaxSatisfyingStrings(versions, c, includePreReleases)
+// Returns evaluator.ErrNoSatisfyingVersion if no version satisfies the constraint
+// DEPRECATED: Use MaxSatisfyingStringsWithEcosystem for new code. Defaults to NodeJS boilerplates.
+func MaxSatisfyingStrings(versionStrings []string, c constraints.Constraint, includePreReleases bool) (versions.Semver, error) {
+	return MaxSatisfyingStringsWithEcosystem(versionStrings, c, NodeJS, includePreReleases)
+}
+
+// Evaluates the given constraints for each provided version of the ecosystem and returns the hightest version that satisfies this constraint
+// The versions are parsed and compared by the registered ecosystem, and prereleases satisfy the constraint as in SatisfiesWithEcosystem
+// Returns evaluator.ErrNoSatisfyingVersion if no version satisfies the constraint
+//
+//	ex: versions '1.0.0', '1.1.0-RC1', '1.2.0-dev', constraint '^1.0' would return '1.0.0' for NodeJS, and '1.0.0' for Composer
+//	ex: versions '1.0.0', '1.1.0-RC1', '1.2.0-dev', constraint '^1.0@RC' would return '1.1.0-RC1' for Composer
+//	ex: versions '1.0.0', '1.1.0-RC1', '1.2.0-dev', constraint '^1.0' and includePreReleases would return '1.2.0-dev' for Composer
+//
+// Composer versions satisfy the constraint as in SatisfiesWithEcosystem, use MaxSatisfyingComposer for other ComposerOptions
+func MaxSatisfyingStringsWithEcosystem(versionStrings []string, c constraints.Constraint, ecosystem EcosystemType, includePreReleases bool) (versions.Semver, error) {
+	e := LookupEcosystem(ecosystem)
+	max := versions.Semver{}
+	found := false
+	for _, versionString := range versionStrings {
+		parsedVersion, err := e.ParseVersion(versionString)
+		if err != nil {
+			return versions.Semver{}, err
+		}
+		if e.Satisfies(parsedVersion, c, includePreReleases) && (!found || e.Compare(parsedVersion, max) > 0) {
+			max, found = parsedVersion, true
+		}
+	}
+	if !found {
+		return versions.Semver{}, evaluator.ErrNoSatisfyingVersion
+	}
+	return max, nil
+}
+
+// Takes all published versions of a package and a semver constraint
+// Returns a shorter constraint that is satisfied by the same published versions, or the given constraint if it is already shorter
+//
+//	ex: versions '1.0.0', '1.0.1', '1.1.0', '1.2.0' and constraint '1.0.0 || 1.0.1 || 1.1.0' would return '<=1.1.0'
+func Simplify(versions []versions.Semver, c constraints.Constraint) constraints.Constraint {
+	return evaluator.Simplify(versions, c)
 }
 
 // Sort sortes a given array of versions
@@ -81,30 +188,42 @@ func Sort(sortOrder int, versions []versions.Semver) []versions.Semver {
 
 // SortString sortes a given array of versions
 // Equivalent to Sort, but this function allows users to pass in versions as strings
+// DEPRECATED: Use SortStringsWithEcosystem for new code. Defaults to NodeJS boilerplates.
 //
 // descending sort 	if sort order == -1
 //
 // ascending sort 	otherwise
 func SortStrings(sortOrder int, versionStrings []string) ([]string, error) {
+	return SortStringsWithEcosystem(sortOrder, versionStrings, NodeJS)
+}
+
+// SortStringsWithEcosystem sortes a given array of versions of the ecosystem
+// The versions are parsed and compared by the registered ecosystem, and returned as they were given
+//
+// descending sort 	if sort order == -1
+//
+// ascending sort 	otherwise
+func SortStringsWithEcosystem(sortOrder int, versionStrings []string, ecosystem EcosystemType) ([]string, error) {
 	type VersionVariant struct {
 		parsed versions.Semver
 		raw    string
 	}
 
+	e := LookupEcosystem(ecosystem)
 	parsedVersions := make([]VersionVariant, len(versionStrings))
 	for i, versionString := range versionStrings {
-		parsedVersion, err := ParseSemver(versionString)
+		parsedVersion, err := e.ParseVersion(versionString)
 		if err != nil {
 			return nil, err
 		}
 		parsedVersions[i] = VersionVariant{parsed: parsedVersion, raw: versionString}
 	}
 
-	sort.Slice(parsedVersions, func(i, j int) bool {
+	sort.SliceStable(parsedVersions, func(i, j int) bool {
 		if sortOrder == -1 {
-			return parsedVersions[i].parsed.GT(parsedVersions[j].parsed, false)
+			return e.Compare(parsedVersions[i].parsed, parsedVersions[j].parsed) > 0
 		} else {
-			return parsedVersions[i].parsed.LT(parsedVersions[j].parsed, false)
+			return e.Compare(parsedVersions[i].parsed, parsedVersions[j].parsed) < 0
 		}
 	})
 
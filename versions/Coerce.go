@@ -0,0 +1,142 @@
+package versions
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// CoerceOptions configure Coerce
+type CoerceOptions struct {
+	// Extracts the right most version instead of the left most one
+	//   e.g. 1.2.3.4 := 2.3.4 instead of 1.2.3
+	RTL bool
+	// Keeps the prerelease and build metadata that follow the version
+	//   e.g. 1.2.3-beta.1+build := 1.2.3-beta.1+build instead of 1.2.3
+	IncludePreRelease bool
+}
+
+// The part of the coerced string that the version was extracted from
+type Span struct {
+	Start int
+	End   int
+}
+
+// Strings longer than this are not coerced, as in nodesemver
+const maxCoerceLength = 256
+
+// The expressions of nodesemver, where a version part has at most 16 digits
+const (
+	coercePlain                = `(\d{1,16})(?:\.(\d{1,16}))?(?:\.(\d{1,16}))?`
+	coercePreReleaseIdentifier = `(?:\d*[a-zA-Z-][a-zA-Z0-9-]*|0|[1-9]\d*)`
+	coerceBuildIdentifier      = `[a-zA-Z0-9-]+`
+)
+
+var (
+	coerceExpression     = regexp.MustCompile(`(^|[^\d])` + coercePlain + `(?:$|[^\d])`)
+	coerceFullExpression = regexp.MustCompile(`(^|[^\d])` + coercePlain +
+		`(?:-(` + coercePreReleaseIdentifier + `(?:\.` + coercePreReleaseIdentifier + `)*))?` +
+		`(?:\+(` + coerceBuildIdentifier + `(?:\.` + coerceBuildIdentifier + `)*))?` +
+		`(?:$|[^\d])`)
+)
+
+// Takes an arbitrary string and extracts the first version-like sequence of one to three numbers, as nodesemver's coerce does
+// Missing minor and patch parts are zero, and anything else is ignored unless the options include the prerelease
+// Returns false if the string does not contain a number
+//
+//	ex: 'release-1.4.7-final' := 1.4.7
+//	ex: 'v2' := 2.0.0
+//	ex: 'OpenSSL 3.0.2 15 Mar 2022' := 3.0.2, and 2022.0.0 with RTL
+//	ex: '42.6.7.9.3-alpha' := 42.6.7, and 7.9.3-alpha with RTL and IncludePreRelease
+func Coerce(s string, opts CoerceOptions) (Semver, bool) {
+	semver, _, ok := CoerceWithSpan(s, opts)
+	return semver, ok
+}
+
+// Equivalent to Coerce, but additionally returns the span of the string that the version was extracted from
+//
+//	ex: 'release-1.4.7-final' := 1.4.7 and the span [8, 13)
+func CoerceWithSpan(s string, opts CoerceOptions) (Semver, Span, bool) {
+	if len(s) > maxCoerceLength {
+		return Semver{}, Span{}, false
+	}
+
+	expression := coerceExpression
+	if opts.IncludePreRelease {
+		expression = coerceFullExpression
+	}
+
+	var match []int
+	if !opts.RTL {
+		match = expression.FindStringSubmatchIndex(s)
+	} else {
+		match = findRightMost(expression, s)
+	}
+	if match == nil {
+		return Semver{}, Span{}, false
+	}
+
+	semver := Semver{}
+	for idx, part := range []*uint64{&semver.Major, &semver.Minor, &semver.Patch} {
+		start, end := match[4+2*idx], match[5+2*idx]
+		if start < 0 {
+			continue
+		}
+		// At most 16 digits always fit
+		*part, _ = strconv.ParseUint(s[start:end], 10, 64)
+	}
+
+	span := Span{Start: match[3]}
+	for group := 2; group*2+1 < len(match); group++ {
+		if match[group*2+1] > span.End {
+			span.End = match[group*2+1]
+		}
+	}
+
+	semver.Raw = s[span.Start:span.End]
+
+	if opts.IncludePreRelease {
+		if match[10] >= 0 {
+			semver.PreReleaseTag = s[match[10]:match[11]]
+		}
+		if match[12] >= 0 {
+			semver.MetaData = s[match[12]:match[13]]
+		}
+	}
+
+	return semver, span, true
+}
+
+// Returns the match that ends right most, of those the longest, as nodesemver's coerce does with the rtl option
+// Each search starts after the major part of the previous match, such that 1.2.3.4 matches 1.2.3, then 2.3.4 which ends at the end
+func findRightMost(expression *regexp.Regexp, s string) []int {
+	var match []int
+	offset := 0
+
+	for offset <= len(s) {
+		next := expression.FindStringSubmatchIndex(s[offset:])
+		if next == nil {
+			break
+		}
+		for idx := range next {
+			if next[idx] >= 0 {
+				next[idx] += offset
+			}
+		}
+
+		// A search that does not start at the beginning of the string cannot match its start
+		if offset > 0 && next[0] == offset && next[3] == offset {
+			offset++
+			continue
+		}
+
+		if match == nil || next[1] != match[1] {
+			match = next
+		}
+		if match[1] == len(s) {
+			break
+		}
+		offset = next[5]
+	}
+
+	return match
+}
@@ -0,0 +1,353 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// The stabilities of Composer, from the least to the most stable
+// Patch releases, e.g. 1.0.0-patch1, are stable and follow the release they patch
+var ComposerStabilities = []string{"dev", "alpha", "beta", "RC", "stable"}
+
+// The stabilities of Composer prereleases in the order of their precedence, as VersionParser::normalize expands them
+// Prereleases that do not start with a stability, e.g. the -0 prerelease of a desugared constraint, precede all of them
+var composerPreReleaseStabilities = append(slices.Clone(ComposerStabilities), "patch")
+
+// The expressions of Composer's VersionParser::normalize
+const composerModifier = `[._-]?(?:(stable|beta|b|RC|alpha|a|patch|pl|p)((?:[.-]?\d+)*)?)?([.-]?dev)?`
+
+// Reasons for a branch alias to be rejected
+var (
+	ErrInvalidBranchAlias = errors.New("branch alias must be a numbered version, e.g. 1.0.x-dev")
+	ErrNotABranch         = errors.New("only dev branches can be aliased")
+)
+
+var (
+	composerInlineAlias      = regexp.MustCompile(`^([^,\s]+) +as +([^,\s]+)$`)
+	composerClassicalVersion = regexp.MustCompile(`(?i)^v?(\d{1,5})(\.\d+)?(\.\d+)?(\.\d+)?` + composerModifier + `$`)
+	composerDateVersion      = regexp.MustCompile(`(?i)^v?(\d{4}(?:[.:-]?\d{2}){1,6}(?:[.:-]?\d{1,3}){0,2})` + composerModifier + `$`)
+	composerNonDigits        = regexp.MustCompile(`\D+`)
+	composerModifierOnly     = regexp.MustCompile(`(?i)^` + composerModifier + `$`)
+)
+
+// Parses a Composer version, as Composer's VersionParser::normalize does
+// Versions have up to four numeric parts, and a modifier whose stability is expanded, e.g. b := beta and pl := patch
+// Wildcard parts of branches are parsed as 9999999, as are all parts that follow them
+//
+//	ex: '1.2' := 1.2.0.0
+//	ex: '1.2.3.4' := 1.2.3.4
+//	ex: '1.0.0-beta1' := 1.0.0.0-beta1
+//	ex: 'v2.0.0-p1' := 2.0.0.0-patch1
+//	ex: '1.0.0-RC2-dev' := 1.0.0.0-RC2 and IsDev
+//	ex: '1.0.x-dev' := 1.0.9999999.9999999 and IsDev
+//	ex: '2023-10-15' := 2023.10.15.0
+//
+// A dev branch with an inline alias compares as its alias, see WithBranchAlias
+//
+//	ex: 'dev-main as 1.0.x-dev' := 1.0.9999999.9999999 and IsDev, of the branch main
+func parseComposer(versionLiteral string) (Semver, error) {
+	literal := strings.TrimSpace(versionLiteral)
+	if match := composerInlineAlias.FindStringSubmatch(literal); match != nil {
+		semver, err := parseComposerVersion(versionLiteral, match[1])
+		if err != nil {
+			return Semver{}, err
+		}
+		return semver.aliasBranch(match[2])
+	}
+	return parseComposerVersion(versionLiteral, literal)
+}
+
+func parseComposerVersion(versionLiteral string, literal string) (Semver, error) {
+	semver := Semver{Raw: versionLiteral, Ecosystem: COMPOSER_ECOSYSTEM}
+
+	// Handle stability flags (@stable, @RC, etc.)
+	if idx := strings.LastIndex(literal, "@"); idx >= 0 {
+		semver.Stability = literal[idx+1:]
+		literal = literal[:idx]
+	}
+
+	if strings.HasPrefix(strings.ToLower(literal), "dev-") {
+		semver.IsDev = true
+		semver.DevBranch = literal[len("dev-"):]
+		// Dev versions are considered maximum versions for their branch
+		semver.Major = composerWildcard
+		semver.Minor = composerWildcard
+		semver.Patch = composerWildcard
+		semver.Revision = composerWildcard
+		return semver, nil
+	}
+
+	// Composer ignores build metadata, it is kept such that the version can be formatted as it was written
+	literal, semver.MetaData, _ = strings.Cut(literal, "+")
+	if err := validateIdentifiers(versionLiteral, META_DATA_PART, semver.MetaData, false); err != nil {
+		return Semver{}, err
+	}
+
+	literal, wildcardIdx := expandComposerWildcards(literal)
+
+	numbers := ""
+	match := composerClassicalVersion.FindStringSubmatch(literal)
+	if match != nil {
+		numbers = strings.Join(match[1:5], "")
+	} else if match = composerDateVersion.FindStringSubmatch(literal); match != nil {
+		numbers = composerNonDigits.ReplaceAllString(match[1], ".")
+		// The date and the modifier take the places of the four parts in the match
+		match = append([]string{match[0], "", "", "", ""}, match[2:]...)
+	} else {
+		return Semver{}, ErrInvalidVersionParts
+	}
+
+	parts := strings.Split(numbers, ".")
+	if len(parts) > 4 {
+		return Semver{}, ErrInvalidVersionParts
+	}
+	for idx, part := range parts {
+		parsed, err := parseVersionPart(versionLiteral, idx, part)
+		if err != nil {
+			return Semver{}, err
+		}
+
+		switch idx {
+		case 0:
+			semver.Major = parsed
+		case 1:
+			semver.Minor = parsed
+		case 2:
+			semver.Patch = parsed
+		case 3:
+			semver.Revision = parsed
+		}
+	}
+	// A wildcard stands in for all parts that follow it, e.g. 1.x-dev := 1.9999999.9999999.9999999
+	if wildcardIdx >= 0 {
+		semver.Revision = composerWildcard
+		if wildcardIdx < 2 {
+			semver.Patch = composerWildcard
+		}
+	}
+
+	if stability := expandComposerStability(match[5]); stability != "" && stability != "stable" {
+		semver.PreReleaseTag = stability + strings.TrimLeft(match[6], ".-")
+	}
+	semver.IsDev = match[7] != ""
+
+	return semver, nil
+}
+
+// Wildcard parts are replaced by this placeholder, which the expressions take as a number
+const wildcardPlaceholder = "9999999"
+
+// Replaces the wildcard parts of a branch by the placeholder
+// Returns the index of the first wildcard part, or -1
+//
+//	ex: '1.0.x-dev' := 1.0.9999999-dev and 2
+func expandComposerWildcards(literal string) (string, int) {
+	wildcardIdx := -1
+	parts := strings.Split(literal, ".")
+	for idx, part := range parts {
+		if idx == 0 {
+			continue
+		}
+		for _, wildcard := range []string{"x", "X", "*"} {
+			if part == wildcard || strings.HasPrefix(part, wildcard+"-") {
+				parts[idx] = wildcardPlaceholder + strings.TrimPrefix(part, wildcard)
+				if wildcardIdx < 0 {
+					wildcardIdx = idx
+				}
+			}
+		}
+	}
+	return strings.Join(parts, "."), wildcardIdx
+}
+
+// Returns true if the version is a dev branch without an alias, e.g. dev-main
+// Such a branch has no place among the numbered versions, it only satisfies its exact dev-<branch> constraint
+func (v Semver) IsUnaliasedBranch() bool {
+	return v.DevBranch != "" && v.BranchAlias == ""
+}
+
+// Takes the branch aliases of a Composer package, as in its extra.branch-alias
+// Returns the version aliased to its branch alias, such that the branch compares as its alias, or the version itself if it has no alias
+// Returns an InvalidVersionError wrapping ErrInvalidBranchAlias if the alias is not a numbered version
+//
+//	ex: 'dev-main' and aliases {dev-main: 2.1.x-dev} := 2.1.9999999.9999999 and IsDev, of the branch main
+//	ex: 'dev-feature' and aliases {dev-main: 2.1.x-dev} := dev-feature
+//
+// An inline alias takes precedence over the branch aliases, as for root requirements in Composer
+//
+//	ex: 'dev-main as 1.0.x-dev' and aliases {dev-main: 2.1.x-dev} := 1.0.9999999.9999999 and IsDev, of the branch main
+func (v Semver) WithBranchAlias(branchAliases map[string]string) (Semver, error) {
+	if !v.IsUnaliasedBranch() {
+		return v, nil
+	}
+	alias, ok := branchAliases["dev-"+v.DevBranch]
+	if !ok {
+		return v, nil
+	}
+	return v.aliasBranch(alias)
+}
+
+func (v Semver) aliasBranch(aliasLiteral string) (Semver, error) {
+	if v.DevBranch == "" {
+		return Semver{}, &InvalidVersionError{Version: v.Raw, Part: RELEASE_PART, Identifier: v.Raw, Reason: ErrNotABranch}
+	}
+	alias, err := parseComposerVersion(aliasLiteral, aliasLiteral)
+	if err != nil || alias.DevBranch != "" {
+		return Semver{}, &InvalidVersionError{Version: v.Raw, Part: RELEASE_PART, Identifier: aliasLiteral, Reason: ErrInvalidBranchAlias}
+	}
+
+	alias.Raw = v.Raw
+	alias.DevBranch = v.DevBranch
+	alias.BranchAlias = aliasLiteral
+	if alias.Stability == "" {
+		alias.Stability = v.Stability
+	}
+	return alias, nil
+}
+
+// Returns the stability of a modifier as Composer writes it
+//
+//	ex: 'b' := beta
+//	ex: 'pl' := patch
+func expandComposerStability(modifier string) string {
+	switch strings.ToLower(modifier) {
+	case "a":
+		return "alpha"
+	case "b":
+		return "beta"
+	case "p", "pl":
+		return "patch"
+	case "rc":
+		return "RC"
+	default:
+		return strings.ToLower(modifier)
+	}
+}
+
+// Returns the modifier of a Composer version, i.e. its prerelease, in the form that VersionParser::normalize gives it
+// Returns false if it is not a modifier that Composer accepts
+//
+//	ex: '-b1' := -beta1
+//	ex: '-rc.2' := -RC2
+//	ex: '-pl1' := -patch1
+//	ex: '-stable' := empty
+func NormalizeComposerModifier(modifier string) (string, bool) {
+	match := composerModifierOnly.FindStringSubmatch(modifier)
+	if match == nil {
+		return "", false
+	}
+
+	normalized := ""
+	if stability := expandComposerStability(match[1]); stability != "" && stability != "stable" {
+		normalized = "-" + stability + strings.TrimLeft(match[2], ".-")
+	}
+	if match[3] != "" {
+		normalized += "-dev"
+	}
+	return normalized, true
+}
+
+// Returns the normalized form of a Composer version, as Composer's VersionParser::normalize does
+//
+//	ex: '1.2' := 1.2.0.0
+//	ex: '1.0.0-pl1' := 1.0.0.0-patch1
+//	ex: '1.0.0-RC2-dev' := 1.0.0.0-RC2-dev
+//	ex: 'dev-main' := dev-main
+func NormalizeComposer(versionLiteral string) (string, error) {
+	semver, err := parseComposer(versionLiteral)
+	if err != nil {
+		return "", err
+	}
+
+	if semver.DevBranch != "" {
+		return "dev-" + semver.DevBranch, nil
+	}
+
+	normalized := fmt.Sprintf("%d.%d.%d.%d", semver.Major, semver.Minor, semver.Patch, semver.Revision)
+	if semver.PreReleaseTag != "" {
+		normalized += "-" + semver.PreReleaseTag
+	}
+	if semver.IsDev {
+		normalized += "-dev"
+	}
+	return normalized, nil
+}
+
+// Returns the stability of a Composer version, one of ComposerStabilities
+//
+//	ex: '1.0.0-beta1' := beta
+//	ex: '1.0.0-patch1' := stable
+//	ex: 'dev-main' := dev
+func (v Semver) ComposerStability() string {
+	if v.IsDev {
+		return "dev"
+	}
+	stability, _ := splitComposerPreRelease(v.PreReleaseTag)
+	if stability == "" || stability == "patch" {
+		return "stable"
+	}
+	return stability
+}
+
+// Splits a Composer prerelease into its stability and its number
+//
+//	ex: 'beta1.2' := beta and 1.2
+//	ex: '0' := '' and 0
+func splitComposerPreRelease(preRelease string) (string, string) {
+	for _, stability := range composerPreReleaseStabilities {
+		if strings.HasPrefix(preRelease, stability) {
+			return stability, strings.TrimLeft(preRelease[len(stability):], ".-")
+		}
+	}
+	return "", preRelease
+}
+
+// Compares the prereleases of two Composer versions with the same numeric parts
+// Stabilities are ordered dev < alpha < beta < RC < stable < patch, then by their number
+// A numbered dev version, e.g. 1.0.0-beta1-dev, precedes the version it leads up to
+//
+//	ex: '1.0.0-dev' < '1.0.0-alpha1' < '1.0.0-beta1' < '1.0.0-RC1' < '1.0.0' < '1.0.0-patch1'
+func compareComposerPreRelease(v1 Semver, v2 Semver) int {
+	if comparison := compareComposerStability(v1, v2); comparison != 0 {
+		return comparison
+	}
+
+	_, number1 := splitComposerPreRelease(v1.PreReleaseTag)
+	_, number2 := splitComposerPreRelease(v2.PreReleaseTag)
+	if comparison := comparePreRelease(strings.ReplaceAll(number1, "-", "."), strings.ReplaceAll(number2, "-", ".")); comparison != 0 {
+		return comparison
+	}
+
+	if v1.IsDev != v2.IsDev {
+		if v1.IsDev {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+func compareComposerStability(v1 Semver, v2 Semver) int {
+	precedence := func(v Semver) int {
+		if v.PreReleaseTag == "" {
+			if v.IsDev {
+				return slices.Index(composerPreReleaseStabilities, "dev")
+			}
+			return slices.Index(composerPreReleaseStabilities, "stable")
+		}
+		stability, _ := splitComposerPreRelease(v.PreReleaseTag)
+		return slices.Index(composerPreReleaseStabilities, stability)
+	}
+
+	precedence1, precedence2 := precedence(v1), precedence(v2)
+	if precedence1 != precedence2 {
+		if precedence1 > precedence2 {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package versions
+
+import (
+	"strconv"
+	"strings"
+)
+
+type VersionFormat string
+
+const (
+	// The SemVer 2.0.0 representation, e.g. 1.0.0-beta+build
+	CANONICAL_FORMAT VersionFormat = "canonical"
+	// The literal the version was parsed from, e.g. v1.0.0-beta+build
+	ORIGINAL_FORMAT VersionFormat = "original"
+	// The representation of the ecosystem, e.g. dev-master, 1.0.x-dev, 1.2.3.4 and 1.0.0-RC2@beta for Composer
+	NATIVE_FORMAT VersionFormat = "native"
+)
+
+// Composer wildcards are parsed as this number, as VersionParser::normalize does, e.g. 1.0.x-dev := 1.0.9999999
+const composerWildcard = 9999999
+
+// Returns the version in the given format
+// Versions that were not parsed have no original literal, for those the canonical format is returned
+//
+//	ex: 'v1.0.0' := 1.0.0 (canonical), v1.0.0 (original), 1.0.0 (native)
+//	ex: 'dev-master' := 9999999.9999999.9999999 (canonical), dev-master (original), dev-master (native)
+//	ex: '1.0.0@beta' := 1.0.0 (canonical), 1.0.0@beta (original), 1.0.0@beta (native)
+//	ex: 'dev-main as 1.0.x-dev' := 1.0.9999999 (canonical), dev-main as 1.0.x-dev (original), dev-main as 1.0.x-dev (native)
+func (v Semver) Format(format VersionFormat) string {
+	switch format {
+	case ORIGINAL_FORMAT:
+		if v.Raw != "" {
+			return v.Raw
+		}
+		return v.String()
+	case NATIVE_FORMAT:
+		return v.nativeString()
+	default:
+		return v.String()
+	}
+}
+
+// Returns the version as the ecosystem writes it, only Composer versions differ from the canonical format
+func (v Semver) nativeString() string {
+	versionString := v.String()
+
+	if v.DevBranch != "" {
+		versionString = "dev-" + v.DevBranch
+		if v.BranchAlias != "" {
+			versionString += " as " + v.BranchAlias
+		}
+	} else if v.Ecosystem == COMPOSER_ECOSYSTEM {
+		// The revision is only written if it is given, and wildcard parts of a dev version are written as x, e.g. 1.0.x-dev
+		parts := []string{}
+		for idx, part := range []uint64{v.Major, v.Minor, v.Patch, v.Revision} {
+			if v.IsDev && part == composerWildcard {
+				parts = append(parts, "x")
+				break
+			}
+			if idx == 3 && part == 0 {
+				break
+			}
+			parts = append(parts, strconv.FormatUint(part, 10))
+		}
+		versionString = strings.Join(parts, ".")
+
+		if v.PreReleaseTag != "" {
+			versionString += "-" + v.PreReleaseTag
+		}
+		if v.IsDev {
+			versionString += "-dev"
+		}
+		if v.MetaData != "" {
+			versionString += "+" + v.MetaData
+		}
+	}
+
+	if v.Stability != "" {
+		versionString += "@" + v.Stability
+	}
+
+	return versionString
+}
@@ -0,0 +1,99 @@
+package versions
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ParseOptions configure ParseSemverWithOptions
+// The zero value parses like ParseSemverWithEcosystem for the NodeJS ecosystem
+type ParseOptions struct {
+	Ecosystem string
+	// Normalizes forms that npm accepts in loose mode, e.g. =v1.2.3, v 1.2.3 and 1.2.3beta
+	Loose bool
+	// Only accepts versions that conform to the SemVer 2.0.0 grammar, the ecosystem is ignored
+	// If Loose is set as well, the rewritten literal is validated
+	Strict bool
+}
+
+// A Rewrite records a normalization done in loose mode
+//
+//	ex: '1.2.3beta' := Rewrite{Offset: 5, Original: "", Replacement: "-", Message: "inserted hyphen before prerelease"}
+type Rewrite struct {
+	// Byte offset of the rewritten part within the original literal
+	Offset      int
+	Original    string
+	Replacement string
+	Message     string
+}
+
+// A release directly followed by a letter, e.g. 1.2.3beta
+var releaseWithoutHyphen = regexp.MustCompile(`^\d+\.\d+\.\d+[A-Za-z]`)
+
+// Parses a semver string into a semver object with the given options
+// In loose mode the rewrites done to the literal before parsing are returned, the returned error refers to the rewritten literal
+//
+//	ex: '=v1.2.3 ' := 1.2.3, with rewrites for '=v' and ' '
+func ParseSemverWithOptions(versionLiteral string, options ParseOptions) (Semver, []Rewrite, error) {
+	ecosystem := options.Ecosystem
+	if ecosystem == "" {
+		ecosystem = NODEJS_ECOSYSTEM
+	}
+
+	original := versionLiteral
+	rewrites := []Rewrite{}
+	if options.Loose {
+		versionLiteral, rewrites = NormalizeLoose(versionLiteral)
+	}
+
+	var semver Semver
+	var err error
+	if options.Strict {
+		semver, err = parseStrict(versionLiteral)
+	} else {
+		semver, err = ParseSemverWithEcosystem(versionLiteral, ecosystem)
+	}
+	if err != nil {
+		return Semver{}, rewrites, err
+	}
+
+	// The original literal is kept, such that the version can be shown as it was written
+	semver.Raw = original
+	return semver, rewrites, nil
+}
+
+// Returns the literal with the forms that npm accepts in loose mode rewritten into their regular form, and the rewrites done
+//
+//	ex: ' =v1.2.3' := 1.2.3
+//	ex: 'v 1.2.3' := 1.2.3
+//	ex: '1.2.3beta' := 1.2.3-beta
+//	ex: '1.2.3\r' := 1.2.3
+//
+// A single 'v' prefix is accepted outside of loose mode, so it is kept and not reported
+func NormalizeLoose(versionLiteral string) (string, []Rewrite) {
+	rewrites := []Rewrite{}
+	offset := 0
+
+	prefix := versionLiteral[:len(versionLiteral)-len(strings.TrimLeft(versionLiteral, " \t\r\n=vV"))]
+	if prefix != "" && prefix != "v" {
+		rewrites = append(rewrites, Rewrite{Offset: 0, Original: prefix, Replacement: "", Message: "removed prefix"})
+		versionLiteral = versionLiteral[len(prefix):]
+		offset = len(prefix)
+	}
+
+	// Rewrites are ordered by their offset, so the trailing whitespace is reported last
+	trailing := []Rewrite{}
+	if trimmed := strings.TrimRight(versionLiteral, " \t\r\n"); trimmed != versionLiteral {
+		trailing = append(trailing, Rewrite{Offset: offset + len(trimmed), Original: versionLiteral[len(trimmed):], Replacement: "", Message: "removed trailing whitespace"})
+		versionLiteral = trimmed
+	}
+
+	release := strings.TrimPrefix(versionLiteral, "v")
+	if releaseWithoutHyphen.MatchString(release) {
+		idx := len(releaseWithoutHyphen.FindString(release)) - 1 + len(versionLiteral) - len(release)
+		rewrites = append(rewrites, Rewrite{Offset: offset + idx, Original: "", Replacement: "-", Message: "inserted hyphen before prerelease"})
+		versionLiteral = versionLiteral[:idx] + "-" + versionLiteral[idx:]
+	}
+
+	return versionLiteral, append(rewrites, trailing...)
+}
@@ -0,0 +1,137 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Reasons for a version to be rejected, wrapped by an InvalidVersionError
+var (
+	ErrMissingVersionPart  = errors.New("version must consist of a major, minor and patch part")
+	ErrLeadingZero         = errors.New("numeric identifier must not have leading zeros")
+	ErrEmptyIdentifier     = errors.New("identifier must not be empty")
+	ErrInvalidCharacter    = errors.New("identifier must only contain ASCII alphanumerics and hyphens")
+	ErrNonNumericPart      = errors.New("version part must be numeric")
+	ErrVersionPartOverflow = errors.New("version part must not exceed 18446744073709551615")
+)
+
+type VersionPart string
+
+const (
+	RELEASE_PART     VersionPart = "release"
+	MAJOR_PART       VersionPart = "major"
+	MINOR_PART       VersionPart = "minor"
+	PATCH_PART       VersionPart = "patch"
+	REVISION_PART    VersionPart = "revision"
+	PRE_RELEASE_PART VersionPart = "prerelease"
+	META_DATA_PART   VersionPart = "metadata"
+)
+
+// An InvalidVersionError gives the reason and the part of a version that is not valid
+// It wraps the reason, e.g. ErrLeadingZero, and the error of the part, e.g. ErrInvalidPreRelease, such that both can be matched with errors.Is
+//
+//	ex: '1.2.3-alpha.01' := InvalidVersionError{Part: PRE_RELEASE_PART, Identifier: "01", Reason: ErrLeadingZero}
+type InvalidVersionError struct {
+	Version    string
+	Part       VersionPart
+	Identifier string
+	Reason     error
+}
+
+func (e *InvalidVersionError) Error() string {
+	return fmt.Sprintf("invalid version '%s': %s identifier '%s': %s", e.Version, e.Part, e.Identifier, e.Reason)
+}
+
+func (e *InvalidVersionError) Unwrap() []error {
+	switch e.Part {
+	case PRE_RELEASE_PART:
+		return []error{e.Reason, ErrInvalidPreRelease}
+	case META_DATA_PART:
+		return []error{e.Reason, ErrInvalidMetaData}
+	default:
+		return []error{e.Reason, ErrInvalidVersionParts}
+	}
+}
+
+// Parses a version that conforms to the SemVer 2.0.0 grammar
+// Everything after the first '+' is build metadata, e.g. 1.0.0+build-rc has no prerelease but the metadata build-rc
+//
+//	ex: '1.0.0-alpha.1+001' := 1.0.0-alpha.1+001
+//	ex: '01.0.0' := ErrLeadingZero
+//	ex: '1.0' := ErrMissingVersionPart
+//	ex: '1.0.0-alpha..1' := ErrEmptyIdentifier
+func parseStrict(versionLiteral string) (Semver, error) {
+	rest, metaData, hasMetaData := strings.Cut(versionLiteral, "+")
+	release, preRelease, hasPreRelease := strings.Cut(rest, "-")
+
+	parts := strings.Split(release, ".")
+	if len(parts) != 3 {
+		return Semver{}, &InvalidVersionError{Version: versionLiteral, Part: RELEASE_PART, Identifier: release, Reason: ErrMissingVersionPart}
+	}
+
+	numbers := [3]uint64{}
+	for idx, part := range parts {
+		partName := []VersionPart{MAJOR_PART, MINOR_PART, PATCH_PART}[idx]
+		if part == "" {
+			return Semver{}, &InvalidVersionError{Version: versionLiteral, Part: partName, Identifier: part, Reason: ErrEmptyIdentifier}
+		}
+		if !isNumericIdentifier(part) {
+			return Semver{}, &InvalidVersionError{Version: versionLiteral, Part: partName, Identifier: part, Reason: ErrNonNumericPart}
+		}
+		if len(part) > 1 && part[0] == '0' {
+			return Semver{}, &InvalidVersionError{Version: versionLiteral, Part: partName, Identifier: part, Reason: ErrLeadingZero}
+		}
+		number, err := parseVersionPart(versionLiteral, idx, part)
+		if err != nil {
+			return Semver{}, err
+		}
+		numbers[idx] = number
+	}
+
+	if hasPreRelease {
+		if err := validateIdentifiers(versionLiteral, PRE_RELEASE_PART, preRelease, true); err != nil {
+			return Semver{}, err
+		}
+	}
+	if hasMetaData {
+		if err := validateIdentifiers(versionLiteral, META_DATA_PART, metaData, true); err != nil {
+			return Semver{}, err
+		}
+	}
+
+	return Semver{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], PreReleaseTag: preRelease, MetaData: metaData, Raw: versionLiteral}, nil
+}
+
+// Validates the dot separated identifiers of a prerelease or metadata part
+// Identifiers may only contain ASCII alphanumerics and hyphens, and numeric prerelease identifiers must not have leading zeros
+// Empty identifiers are only rejected if requested, as nodesemver accepts them outside of strict mode
+func validateIdentifiers(versionLiteral string, part VersionPart, identifiers string, rejectEmpty bool) error {
+	for _, identifier := range strings.Split(identifiers, ".") {
+		if identifier == "" {
+			if rejectEmpty {
+				return &InvalidVersionError{Version: versionLiteral, Part: part, Identifier: identifier, Reason: ErrEmptyIdentifier}
+			}
+			continue
+		}
+		for _, ch := range identifier {
+			if !(ch >= '0' && ch <= '9') && !(ch >= 'a' && ch <= 'z') && !(ch >= 'A' && ch <= 'Z') && ch != '-' {
+				return &InvalidVersionError{Version: versionLiteral, Part: part, Identifier: identifier, Reason: ErrInvalidCharacter}
+			}
+		}
+		// Build metadata does not take part in precedence, so its numeric identifiers may have leading zeros, e.g. 1.0.0-alpha+001
+		if part == PRE_RELEASE_PART && isNumericIdentifier(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return &InvalidVersionError{Version: versionLiteral, Part: part, Identifier: identifier, Reason: ErrLeadingZero}
+		}
+	}
+	return nil
+}
+
+func isNumericIdentifier(identifier string) bool {
+	for _, ch := range identifier {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return identifier != ""
+}
@@ -1,6 +1,7 @@
 package versions
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"strconv"
@@ -8,18 +9,32 @@ import (
 )
 
 type Semver struct {
-	Major         int
-	Minor         int
-	Patch         int
+	Major         uint64
+	Minor         uint64
+	Patch         uint64
 	PreReleaseTag string
 	MetaData      string
 
+	// The literal the version was parsed from, empty for versions that were not parsed
+	Raw string
+
 	// Composer-specific fields
-	IsDev     bool   // true for dev versions (dev-master, 1.0.x-dev)
-	DevBranch string // branch name for dev-* versions
-	Stability string // stability flag (@stable, @RC, @beta, @alpha, @dev)
+	Revision    uint64 // fourth numeric part, e.g. 4 for 1.2.3.4
+	IsDev       bool   // true for dev versions (dev-master, 1.0.x-dev)
+	DevBranch   string // branch name for dev-* versions
+	BranchAlias string // version a dev branch is aliased to (2.1.x-dev for dev-main), the branch then compares as its alias
+	Stability   string // stability flag (@stable, @RC, @beta, @alpha, @dev)
+
+	// The ecosystem whose precedence rules apply, composer for Composer versions and empty for SemVer
+	Ecosystem string
 }
 
+// The ecosystems whose versions are parsed by ParseSemverWithEcosystem, versions of other ecosystems are parsed as nodejs versions
+const (
+	NODEJS_ECOSYSTEM   = "nodejs"
+	COMPOSER_ECOSYSTEM = "composer"
+)
+
 var (
 	ErrInvalidVersionParts = errors.New("invalid version parts")
 	ErrInvalidPreRelease   = errors.New("invalid pre release")
@@ -27,60 +42,30 @@ var (
 )
 
 // ParseSemverWithEcosystem parses a semver string into a semver object for specified ecosystem
+// Only the built-in ecosystems are known, any other is parsed as nodejs, use semver.ParseSemverWithEcosystem for registered ecosystems
+// The prerelease and metadata identifiers are taken as they are, use ParseOptions.Strict to validate them, e.g. 1.2.3-beta_1 and 1.2.3-01 are accepted
 func ParseSemverWithEcosystem(versionLiteral string, ecosystem string) (Semver, error) {
 	if versionLiteral == "" {
 		return Semver{}, ErrInvalidVersionParts
 	}
 
-	semver := Semver{}
-
-	// Handle Composer-specific dev versions
-	if ecosystem == "composer" {
-		if strings.HasPrefix(versionLiteral, "dev-") {
-			semver.IsDev = true
-			semver.DevBranch = strings.TrimPrefix(versionLiteral, "dev-")
-			// Dev versions are considered maximum versions for their branch
-			semver.Major = 999
-			semver.Minor = 999
-			semver.Patch = 999
-			return semver, nil
-		}
-
-		if strings.HasSuffix(versionLiteral, "-dev") {
-			semver.IsDev = true
-			versionLiteral = strings.TrimSuffix(versionLiteral, "-dev")
-		}
-
-		// Handle stability flags (@stable, @RC, etc.)
-		if strings.Contains(versionLiteral, "@") {
-			parts := strings.Split(versionLiteral, "@")
-			if len(parts) == 2 {
-				versionLiteral = parts[0]
-				semver.Stability = parts[1]
-			}
-		}
+	if ecosystem == COMPOSER_ECOSYSTEM {
+		return parseComposer(versionLiteral)
 	}
 
+	semver := Semver{Raw: versionLiteral}
+
 	// Remove 'v' prefix if present
 	versionLiteral = strings.TrimPrefix(versionLiteral, "v")
 
 	versionParts := strings.Split(GetVersionPart(versionLiteral), ".")
 
-	// Handle x.y.x patterns for Composer
-	if ecosystem == "composer" {
-		for i, part := range versionParts {
-			if part == "x" || part == "X" {
-				versionParts[i] = "999" // Treat wildcards as high numbers
-			}
-		}
-	}
-
 	if len(versionParts) != 3 {
 		if len(versionParts) == 2 {
 			for i, part := range versionParts {
-				parsed, err := strconv.Atoi(part)
+				parsed, err := parseVersionPart(versionLiteral, i, part)
 				if err != nil {
-					return Semver{}, ErrInvalidVersionParts
+					return Semver{}, err
 				}
 
 				switch i {
@@ -97,9 +82,9 @@ func ParseSemverWithEcosystem(versionLiteral string, ecosystem string) (Semver,
 		}
 	} else {
 		for i, part := range versionParts {
-			parsed, err := strconv.Atoi(part)
+			parsed, err := parseVersionPart(versionLiteral, i, part)
 			if err != nil {
-				return Semver{}, ErrInvalidVersionParts
+				return Semver{}, err
 			}
 
 			switch i {
@@ -122,263 +107,128 @@ func ParseSemverWithEcosystem(versionLiteral string, ecosystem string) (Semver,
 // Parses a semver string into a semver object
 // DEPRECATED: Use ParseSemverWithEcosystem for new code. Defaults to NodeJS boilerplates.
 func ParseSemver(versionLiteral string) (Semver, error) {
-	return ParseSemverWithEcosystem(versionLiteral, "nodejs")
+	return ParseSemverWithEcosystem(versionLiteral, NODEJS_ECOSYSTEM)
 }
 
 // Compares versions v1 and v2, and returns true if v1 >= v2 and false otherwise
-// This comparision is done according to the semver 2.0 spec with Composer extensions
+// This comparision is done according to the semver 2.0 spec with Composer extensions, see compareVersions
 func (v1 Semver) GE(v2 Semver, ignorePreRelease bool) bool {
-	// Handle dev version comparisons for Composer
-	if v1.IsDev && v2.IsDev {
-		// Both dev versions - compare by branch name
-		if v1.DevBranch != v2.DevBranch {
-			return v1.DevBranch >= v2.DevBranch
-		}
-		return true // Same dev branch is equal
-	}
-
-	if v1.IsDev && !v2.IsDev {
-		// Dev version is higher than stable version of same base
-		return true
-	}
-
-	if !v1.IsDev && v2.IsDev {
-		// Stable version is lower than dev version
-		return false
-	}
-
-	if v1.Major != v2.Major {
-		return v1.Major > v2.Major
-	}
-
-	if v1.Minor != v2.Minor {
-		return v1.Minor > v2.Minor
-	}
-
-	if v1.Patch != v2.Patch {
-		return v1.Patch > v2.Patch
-	}
-
-	// both are equal in terms of [major, minor, patch] at this point
-	if ignorePreRelease {
-		return true
-	}
-
-	// As per semver spec:
-	//   When major, minor, and patch are equal, a pre-release version has lower precedence than a normal version:
-	//
-	//   5.0.0 >= 5.0.0-beta.5 returns true since 5.0.0 is considered greater than 5.0.0-beta.5
-	if v1.PreReleaseTag == "" && v2.PreReleaseTag != "" {
-		return true
-	} else if v1.PreReleaseTag != "" && v2.PreReleaseTag == "" {
-		return false
-	}
-
-	preReleaseComparison := comparePreRelease(v1.PreReleaseTag, v2.PreReleaseTag)
-	return preReleaseComparison >= 0
+	return compareVersions(v1, v2, ignorePreRelease) >= 0
 }
 
 // Compares versions v1 and v2, and returns true if v1 > v2 and false otherwise
-// This comparision is done according to the semver 2.0 spec
+// This comparision is done according to the semver 2.0 spec with Composer extensions, see compareVersions
 func (v1 Semver) GT(v2 Semver, ignorePreRelease bool) bool {
-	if v1.Major != v2.Major {
-		return v1.Major > v2.Major
-	}
-
-	if v1.Minor != v2.Minor {
-		return v1.Minor > v2.Minor
-	}
-
-	if v1.Patch != v2.Patch {
-		return v1.Patch > v2.Patch
-	}
-
-	// both are equal in terms of [major, minor, patch] at this point
-	if ignorePreRelease {
-		return false
-	}
-
-	// As per semver spec:
-	//   When major, minor, and patch are equal, a pre-release version has lower precedence than a normal version:
-	//
-	//   5.0.0 > 5.0.0-beta.5 returns true since 5.0.0 is considered greater than 5.0.0-beta.5
-	if v1.PreReleaseTag == "" && v2.PreReleaseTag != "" {
-		return true
-	} else if v1.PreReleaseTag != "" && v2.PreReleaseTag == "" {
-		return false
-	}
-
-	preReleaseComparison := comparePreRelease(v1.PreReleaseTag, v2.PreReleaseTag)
-	return preReleaseComparison == 1
+	return compareVersions(v1, v2, ignorePreRelease) > 0
 }
 
 // Compares versions v1 and v2, and returns true if v1 <= v2 and false otherwise
-// This comparision is done according to the semver 2.0 spec
+// This comparision is done according to the semver 2.0 spec with Composer extensions, see compareVersions
 func (v1 Semver) LE(v2 Semver, ignorePreRelease bool) bool {
-	if v1.Major != v2.Major {
-		return v1.Major < v2.Major
-	}
-
-	if v1.Minor != v2.Minor {
-		return v1.Minor < v2.Minor
-	}
-
-	if v1.Patch != v2.Patch {
-		return v1.Patch < v2.Patch
-	}
-
-	// both are equal in terms of [major, minor, patch] at this point
-	if ignorePreRelease {
-		return true
-	}
-
-	// As per semver spec:
-	//   When major, minor, and patch are equal, a pre-release version has lower precedence than a normal version:
-	//
-	//   5.0.0 < 5.0.0-beta.5 returns false since 5.0.0 is considered greater than 5.0.0-beta.5
-	if v1.PreReleaseTag == "" && v2.PreReleaseTag != "" {
-		return false
-	} else if v1.PreReleaseTag != "" && v2.PreReleaseTag == "" {
-		return true
-	}
-
-	preReleaseComparison := comparePreRelease(v1.PreReleaseTag, v2.PreReleaseTag)
-	return preReleaseComparison <= 0
+	return compareVersions(v1, v2, ignorePreRelease) <= 0
 }
 
 // Compares versions v1 and v2, and returns true if v1 < v2 and false otherwise
-// This comparision is done according to the semver 2.0 spec
+// This comparision is done according to the semver 2.0 spec with Composer extensions, see compareVersions
 func (v1 Semver) LT(v2 Semver, ignorePreRelease bool) bool {
-	if v1.Major != v2.Major {
-		return v1.Major < v2.Major
-	}
-
-	if v1.Minor != v2.Minor {
-		return v1.Minor < v2.Minor
-	}
-
-	if v1.Patch != v2.Patch {
-		return v1.Patch < v2.Patch
-	}
-
-	// both are equal in terms of [major, minor, patch] at this point
-	if ignorePreRelease {
-		return false
-	}
-
-	// As per semver spec:
-	//   When major, minor, and patch are equal, a pre-release version has lower precedence than a normal version:
-	//
-	//   5.0.0 < 5.0.0-beta.5 returns false since 5.0.0 is considered greater than 5.0.0-beta.5
-	if v1.PreReleaseTag == "" && v2.PreReleaseTag != "" {
-		return false
-	} else if v1.PreReleaseTag != "" && v2.PreReleaseTag == "" {
-		return true
-	}
-
-	preReleaseComparison := comparePreRelease(v1.PreReleaseTag, v2.PreReleaseTag)
-	return preReleaseComparison == -1
+	return compareVersions(v1, v2, ignorePreRelease) < 0
 }
 
 // Compares versions v1 and v2, and returns true if v1 = v2 and false otherwise
-// This comparision is done according to the semver 2.0 spec
+// This comparision is done according to the semver 2.0 spec with Composer extensions, see compareVersions
 func (v1 Semver) EQ(v2 Semver, ignorePreRelease bool) bool {
-	if v1.Major != v2.Major || v1.Minor != v2.Minor || v1.Patch != v2.Patch {
-		return false
-	}
-
-	if ignorePreRelease {
-		return true
-	}
-
-	if (v1.PreReleaseTag == "" && v2.PreReleaseTag != "") || (v1.PreReleaseTag != "" && v2.PreReleaseTag == "") {
-		return false
-	}
-
-	preReleaseComparison := comparePreRelease(v1.PreReleaseTag, v2.PreReleaseTag)
-	return preReleaseComparison == 0
+	return compareVersions(v1, v2, ignorePreRelease) == 0
 }
 
 // Compares versions v1 and v2, and returns true if v1 != v2 and false otherwise
-// This comparision is done according to the semver 2.0 spec
-func (v1 Semver) NEQ(v2 Semver, includePreReleases bool) bool {
-	return !v1.EQ(v2, includePreReleases)
+// This comparision is done according to the semver 2.0 spec with Composer extensions, see compareVersions
+func (v1 Semver) NEQ(v2 Semver, ignorePreRelease bool) bool {
+	return compareVersions(v1, v2, ignorePreRelease) != 0
 }
 
-// Compares versions v1 and v2, and returns 0 if v1 = v2, returns 1 if v1 > v2 and 0 otherwise
-// This comparision is done according to the semver 2.0 spec
+// Compares versions v1 and v2, and returns 0 if v1 = v2, returns 1 if v1 > v2 and -1 otherwise
+// This comparision is done according to the semver 2.0 spec with Composer extensions, see compareVersions
 func (v1 Semver) Compare(v2 Semver, ignorePreRelease bool) int {
-	if v1.EQ(v2, ignorePreRelease) {
+	return compareVersions(v1, v2, ignorePreRelease)
+}
+
+// The comparison that all comparison methods delegate to
+// Returns 0 if v1 = v2, 1 if v1 > v2 and -1 otherwise
+//
+// Versions are compared by their [major, minor, patch, revision] tuple, then by their prerelease unless it is ignored.
+// As per semver spec, when the tuples are equal a prerelease version has lower precedence than a normal version:
+//
+//	5.0.0 > 5.0.0-beta.5 since 5.0.0 is considered greater than 5.0.0-beta.5
+//
+// Composer dev branches without alias have no numbers, they follow all numbered versions and are ordered by their names
+//
+//	ex: 1.0.0 < dev-feature < dev-main
+//	ex: dev-main as 1.0.x-dev < 1.1.0, as an aliased branch compares as its alias
+//
+// The order is total within an ecosystem, build metadata does not take part in it
+func compareVersions(v1 Semver, v2 Semver, ignorePreRelease bool) int {
+	if v1.IsUnaliasedBranch() || v2.IsUnaliasedBranch() {
+		return compareBranches(v1, v2)
+	}
+
+	for _, parts := range [][2]uint64{{v1.Major, v2.Major}, {v1.Minor, v2.Minor}, {v1.Patch, v2.Patch}, {v1.Revision, v2.Revision}} {
+		if comparison := cmp.Compare(parts[0], parts[1]); comparison != 0 {
+			return comparison
+		}
+	}
+
+	// both are equal in terms of [major, minor, patch, revision] at this point
+	if ignorePreRelease {
 		return 0
 	}
-	if v1.GT(v2, ignorePreRelease) {
+	return comparePreReleaseOf(v1, v2)
+}
+
+// Compares two versions of which at least one is a dev branch without alias, the branches follow all other versions
+func compareBranches(v1 Semver, v2 Semver) int {
+	switch {
+	case v1.IsUnaliasedBranch() && v2.IsUnaliasedBranch():
+		return strings.Compare(v1.DevBranch, v2.DevBranch)
+	case v1.IsUnaliasedBranch():
 		return 1
+	default:
+		return -1
 	}
-	return -1
 }
 
-// Returns a string representation of the parsed semver
-// Note: the metadata and prerelease info might not be in the same order
-// as the original string that was parsed
+// Returns the canonical SemVer representation of the parsed semver, e.g. 1.0.0-beta+build
+// Use Format to get the version as it was written, or with the revision of a Composer version
 func (v Semver) String() string {
 
 	versionString := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
 
-	if v.MetaData != "" {
-		versionString += fmt.Sprintf("+%s", v.MetaData)
-	}
-
 	if v.PreReleaseTag != "" {
 		versionString += fmt.Sprintf("-%s", v.PreReleaseTag)
 	}
 
+	if v.MetaData != "" {
+		versionString += fmt.Sprintf("+%s", v.MetaData)
+	}
+
 	return versionString
 
 }
 
+// Returns the build metadata of the literal, i.e. everything after the first '+'
+//
+//	ex: 1.0.0-beta+build-1 := build-1
 func GetMetaDataPart(versionLiteral string) string {
-	if !strings.Contains(versionLiteral, "+") {
-		return ""
-	}
-	if versionLiteral != "" {
-		versionsArr := strings.SplitN(versionLiteral, "+", 2)
-		if len(versionsArr) > 1 {
-			versionLiteral = versionsArr[1]
-		} else {
-			return ""
-		}
-	}
-	if versionLiteral != "" {
-		versionsArr := strings.SplitN(versionLiteral, "-", 2)
-		if len(versionsArr) > 0 {
-			versionLiteral = versionsArr[0]
-		} else {
-			return ""
-		}
-	}
-	return versionLiteral
+	_, metaData, _ := strings.Cut(versionLiteral, "+")
+	return metaData
 }
 
+// Returns the prerelease of the literal, i.e. everything between the first '-' and the build metadata
+//
+//	ex: 1.0.0-beta+build-1 := beta
 func GetPreReleasePart(versionLiteral string) string {
-	if !strings.Contains(versionLiteral, "-") {
-		return ""
-	}
-
-	versionsArr := strings.SplitN(versionLiteral, string('-'), 2)
-	if len(versionsArr) < 2 {
-		return ""
-	}
-
-	versionLiteral = versionsArr[1]
-
-	versionsArr = strings.SplitN(versionLiteral, string('+'), 2)
-	if len(versionsArr) == 0 {
-		return ""
-	}
-
-	versionLiteral = versionsArr[0]
-
-	return versionLiteral
+	versionLiteral, _, _ = strings.Cut(versionLiteral, "+")
+	_, preRelease, _ := strings.Cut(versionLiteral, "-")
+	return preRelease
 }
 
 func GetVersionPart(versionLiteral string) string {
@@ -396,6 +246,22 @@ func GetVersionPart(versionLiteral string) string {
 	return versionLiteral
 }
 
+// Compares the prereleases of two versions with the same numeric parts
+// A version without prerelease has a higher precedence than one with, unless either is a Composer version,
+// whose precedence follows its stability, see compareComposerPreRelease
+func comparePreReleaseOf(v1 Semver, v2 Semver) int {
+	if v1.Ecosystem == COMPOSER_ECOSYSTEM || v2.Ecosystem == COMPOSER_ECOSYSTEM {
+		return compareComposerPreRelease(v1, v2)
+	}
+
+	if v1.PreReleaseTag == "" && v2.PreReleaseTag != "" {
+		return 1
+	} else if v1.PreReleaseTag != "" && v2.PreReleaseTag == "" {
+		return -1
+	}
+	return comparePreRelease(v1.PreReleaseTag, v2.PreReleaseTag)
+}
+
 func comparePreRelease(preRelease1 string, preRelease2 string) int {
 	// According to the semver spec
 	// "Precedence for two pre-release versions with the same major, minor, and patch version
@@ -453,27 +319,22 @@ func comparePreReleaseIdentifier(preRelease1Identifier string, preRelease2Identi
 		return 1
 	}
 
-	isIntPre1Id, err1 := strconv.Atoi(preRelease1Identifier)
-	isIntPre2Id, err2 := strconv.Atoi(preRelease2Identifier)
+	isIntPre1Id := isNumericIdentifier(preRelease1Identifier)
+	isIntPre2Id := isNumericIdentifier(preRelease2Identifier)
 
-	if err1 == nil && err2 == nil {
-		// If both are ints we compare them numerically
-		if isIntPre1Id > isIntPre2Id {
-			return 1
-		} else if isIntPre1Id < isIntPre2Id {
-			return -1
-		}
-		return 0
+	if isIntPre1Id && isIntPre2Id {
+		// If both are ints we compare them numerically, without parsing them, as they may exceed any integer type
+		return compareNumericIdentifiers(preRelease1Identifier, preRelease2Identifier)
 	}
 
 	// According to the semver spec:
 	// "Numeric identifiers always have lower precedence than non-numeric identifiers."
 	// Thus trivially if one is an int but the other is not, then the other is greater
-	if err1 == nil && err2 != nil {
+	if isIntPre1Id && !isIntPre2Id {
 		return -1
 	}
 
-	if err1 != nil && err2 == nil {
+	if !isIntPre1Id && isIntPre2Id {
 		return 1
 	}
 
@@ -483,6 +344,40 @@ func comparePreReleaseIdentifier(preRelease1Identifier string, preRelease2Identi
 
 }
 
+// Compares two numeric identifiers of any length
+// Without leading zeros the longer one is greater, identifiers of the same length compare lexically
+//
+//	ex: '99999999999999999999' > '9'
+func compareNumericIdentifiers(identifier1 string, identifier2 string) int {
+	identifier1 = strings.TrimLeft(identifier1, "0")
+	identifier2 = strings.TrimLeft(identifier2, "0")
+
+	if len(identifier1) != len(identifier2) {
+		if len(identifier1) > len(identifier2) {
+			return 1
+		}
+		return -1
+	}
+	return strings.Compare(identifier1, identifier2)
+}
+
+// Parses a major, minor, patch or revision part, given by its index
+// Parts that exceed the range of uint64 are rejected with ErrVersionPartOverflow rather than wrapped around
+//
+//	ex: '20231015123456' := 20231015123456
+//	ex: '18446744073709551616' := ErrVersionPartOverflow
+func parseVersionPart(versionLiteral string, idx int, part string) (uint64, error) {
+	parsed, err := strconv.ParseUint(part, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			partName := []VersionPart{MAJOR_PART, MINOR_PART, PATCH_PART, REVISION_PART}[idx]
+			return 0, &InvalidVersionError{Version: versionLiteral, Part: partName, Identifier: part, Reason: ErrVersionPartOverflow}
+		}
+		return 0, ErrInvalidVersionParts
+	}
+	return parsed, nil
+}
+
 func IsStaticVersion(versionLiteral string) bool {
 	versionLiteral = GetVersionPart(versionLiteral)
 	return strings.Count(versionLiteral, ".") == 2 && !IsWildCardVersion(versionLiteral)
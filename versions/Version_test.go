@@ -1,7 +1,9 @@
 package versions
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"testing"
 )
 
@@ -10,6 +12,34 @@ type VersionParsingTest struct {
 	ExpectedResult Semver
 }
 
+type LooseParsingTest struct {
+	VersionString    string
+	ExpectedResult   Semver
+	ExpectedRewrites []Rewrite
+}
+
+type StrictParsingTest struct {
+	VersionString  string
+	ExpectedResult Semver
+	ExpectedPart   VersionPart
+	ExpectedReason error
+}
+
+type CoerceTest struct {
+	String         string
+	Options        CoerceOptions
+	ExpectedResult Semver
+	ExpectedSpan   Span
+	ExpectedOk     bool
+}
+
+type FormatTest struct {
+	VersionString     string
+	Ecosystem         string
+	ExpectedCanonical string
+	ExpectedNative    string
+}
+
 type ComparisonToTest[T comparable] struct {
 	v1             Semver
 	v2             Semver
@@ -464,6 +494,19 @@ func TestGTComperatorPreRelease(t *testing.T) {
 			v2:             Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "rc.1"},
 			ExpectedResult: true,
 		},
+		// 1.0.0-rc.100000000000000000000 > 1.0.0-rc.99999999999999999999
+		// Numeric identifiers beyond the range of any integer type are still compared numerically
+		{
+			v1:             Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "rc.100000000000000000000"},
+			v2:             Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "rc.99999999999999999999"},
+			ExpectedResult: true,
+		},
+		// 1.0.0-rc.99999999999999999999 > 1.0.0-rc.a    -> false
+		{
+			v1:             Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "rc.99999999999999999999"},
+			v2:             Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "rc.a"},
+			ExpectedResult: false,
+		},
 	}
 
 	testPreReleaseComparisons(t, tests, ">")
@@ -789,6 +832,18 @@ func TestVersionParsing(t *testing.T) {
 			VersionString:  "5.66",
 			ExpectedResult: Semver{Major: 5, Minor: 66, Patch: 0}, // missing patch
 		},
+		{
+			VersionString:  "20231015123456.0.0",
+			ExpectedResult: Semver{Major: 20231015123456, Minor: 0, Patch: 0}, // date-stamped major
+		},
+		{
+			VersionString:  "18446744073709551615.0.0",
+			ExpectedResult: Semver{Major: 18446744073709551615, Minor: 0, Patch: 0},
+		},
+		{
+			VersionString:  "18446744073709551616.0.0",
+			ExpectedResult: Semver{}, // exceeds uint64
+		},
 
 		// Invalid [major, minor, patch] version parts
 		{
@@ -873,13 +928,14 @@ func TestVersionParsing(t *testing.T) {
 			VersionString:  "1.0.0+exp.sha.5114f85",
 			ExpectedResult: Semver{Major: 1, Minor: 0, Patch: 0, MetaData: "exp.sha.5114f85"},
 		},
+		// Identifiers are only validated in strict mode, see TestStrictVersionParsing
 		{
 			VersionString:  "1.0.0+exp.sha.5114f85()",
-			ExpectedResult: Semver{}, // invalid character in pre release tag
+			ExpectedResult: Semver{Major: 1, Minor: 0, Patch: 0, MetaData: "exp.sha.5114f85()"},
 		},
 		{
 			VersionString:  "1.0.0+21AF26D3+++117B344092BD",
-			ExpectedResult: Semver{}, // invalid character in meta data section
+			ExpectedResult: Semver{Major: 1, Minor: 0, Patch: 0, MetaData: "21AF26D3+++117B344092BD"},
 		},
 
 		// Pre release tag
@@ -893,7 +949,15 @@ func TestVersionParsing(t *testing.T) {
 		},
 		{
 			VersionString:  "1.2.3-alpha-1!",
-			ExpectedResult: Semver{}, // invalid character in pre release tag
+			ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "alpha-1!"},
+		},
+		{
+			VersionString:  "1.2.3-beta_1",
+			ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "beta_1"},
+		},
+		{
+			VersionString:  "1.2.3-01",
+			ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "01"},
 		},
 		{
 			VersionString:  "1.2.3-alpha--------------2",
@@ -905,7 +969,7 @@ func TestVersionParsing(t *testing.T) {
 		},
 		{
 			VersionString:  "4.18.0-next.1599210529.00065",
-			ExpectedResult: Semver{}, // the spec disallows numeric identifiers to have leading zeros, i.e. the 00065 identifier
+			ExpectedResult: Semver{Major: 4, Minor: 18, Patch: 0, PreReleaseTag: "next.1599210529.00065"},
 		},
 
 		// Both build meta data and pre release version
@@ -923,6 +987,231 @@ func TestVersionParsing(t *testing.T) {
 
 }
 
+func TestLooseVersionParsing(t *testing.T) {
+
+	fmt.Printf("\n%s Testing loose version parsing %s\n", "----------------", "----------------")
+
+	tests := []LooseParsingTest{
+		{VersionString: "1.2.3", ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3}, ExpectedRewrites: []Rewrite{}},
+		{VersionString: "v1.2.3", ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3}, ExpectedRewrites: []Rewrite{}},
+		{
+			VersionString:    "=v1.2.3",
+			ExpectedResult:   Semver{Major: 1, Minor: 2, Patch: 3},
+			ExpectedRewrites: []Rewrite{{Offset: 0, Original: "=v", Replacement: "", Message: "removed prefix"}},
+		},
+		{
+			VersionString:    "v 1.2.3",
+			ExpectedResult:   Semver{Major: 1, Minor: 2, Patch: 3},
+			ExpectedRewrites: []Rewrite{{Offset: 0, Original: "v ", Replacement: "", Message: "removed prefix"}},
+		},
+		{
+			VersionString:    "==1.0",
+			ExpectedResult:   Semver{Major: 1, Minor: 0, Patch: 0},
+			ExpectedRewrites: []Rewrite{{Offset: 0, Original: "==", Replacement: "", Message: "removed prefix"}},
+		},
+		{
+			VersionString:    "1.2.3beta",
+			ExpectedResult:   Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "beta"},
+			ExpectedRewrites: []Rewrite{{Offset: 5, Original: "", Replacement: "-", Message: "inserted hyphen before prerelease"}},
+		},
+		{
+			VersionString:  " v1.2.3rc.1+build\r",
+			ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "rc.1", MetaData: "build"},
+			ExpectedRewrites: []Rewrite{
+				{Offset: 0, Original: " v", Replacement: "", Message: "removed prefix"},
+				{Offset: 7, Original: "", Replacement: "-", Message: "inserted hyphen before prerelease"},
+				{Offset: 17, Original: "\r", Replacement: "", Message: "removed trailing whitespace"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		fmt.Printf("\nTesting loose parsing of %q\n", test.VersionString)
+
+		if _, err := ParseSemver(test.VersionString); err == nil && len(test.ExpectedRewrites) > 0 {
+			fmt.Printf("✗ Failed. Expected %q to be rejected outside of loose mode\n", test.VersionString)
+			t.Errorf("✗ Failed. Expected %q to be rejected outside of loose mode\n", test.VersionString)
+		}
+
+		version, rewrites, err := ParseSemverWithOptions(test.VersionString, ParseOptions{Loose: true})
+		if err != nil {
+			fmt.Printf("✗ Failed. Expected: %s, but got error: %s\n", test.ExpectedResult.String(), err)
+			t.Errorf("✗ Failed. Expected: %s, but got error: %s\n", test.ExpectedResult.String(), err)
+		} else if !version.EQ(test.ExpectedResult, false) || version.MetaData != test.ExpectedResult.MetaData {
+			fmt.Printf("✗ Failed. Expected: %s, but got: %s\n", test.ExpectedResult.String(), version.String())
+			t.Errorf("✗ Failed. Expected: %s, but got: %s\n", test.ExpectedResult.String(), version.String())
+		} else if !slices.Equal(rewrites, test.ExpectedRewrites) {
+			fmt.Printf("✗ Failed. Expected rewrites: %v, but got: %v\n", test.ExpectedRewrites, rewrites)
+			t.Errorf("✗ Failed. Expected rewrites: %v, but got: %v\n", test.ExpectedRewrites, rewrites)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestStrictVersionParsing(t *testing.T) {
+
+	fmt.Printf("\n%s Testing strict version parsing %s\n", "----------------", "----------------")
+
+	tests := []StrictParsingTest{
+		{VersionString: "1.2.3", ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3}},
+		{VersionString: "0.0.0-0", ExpectedResult: Semver{Major: 0, Minor: 0, Patch: 0, PreReleaseTag: "0"}},
+		{VersionString: "1.0.0-alpha+001", ExpectedResult: Semver{Major: 1, Minor: 0, Patch: 0, PreReleaseTag: "alpha", MetaData: "001"}},
+		{VersionString: "1.2.3-alpha--------------2", ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "alpha--------------2"}},
+		// Everything after the first '+' is metadata
+		{VersionString: "1.0.0+21AF26D3----117B344092BD", ExpectedResult: Semver{Major: 1, Minor: 0, Patch: 0, MetaData: "21AF26D3----117B344092BD"}},
+
+		{VersionString: "20231015123456.0.0", ExpectedResult: Semver{Major: 20231015123456, Minor: 0, Patch: 0}},
+
+		{VersionString: "01.2.3", ExpectedPart: MAJOR_PART, ExpectedReason: ErrLeadingZero},
+		{VersionString: "1.2.18446744073709551616", ExpectedPart: PATCH_PART, ExpectedReason: ErrVersionPartOverflow},
+		{VersionString: "1.02.3", ExpectedPart: MINOR_PART, ExpectedReason: ErrLeadingZero},
+		{VersionString: "1.2", ExpectedPart: RELEASE_PART, ExpectedReason: ErrMissingVersionPart},
+		{VersionString: "1.2.3.4", ExpectedPart: RELEASE_PART, ExpectedReason: ErrMissingVersionPart},
+		{VersionString: "v1.2.3", ExpectedPart: MAJOR_PART, ExpectedReason: ErrNonNumericPart},
+		{VersionString: "1.x.3", ExpectedPart: MINOR_PART, ExpectedReason: ErrNonNumericPart},
+		{VersionString: "1..3", ExpectedPart: MINOR_PART, ExpectedReason: ErrEmptyIdentifier},
+		{VersionString: "1.2.3-", ExpectedPart: PRE_RELEASE_PART, ExpectedReason: ErrEmptyIdentifier},
+		{VersionString: "1.2.3-alpha..1", ExpectedPart: PRE_RELEASE_PART, ExpectedReason: ErrEmptyIdentifier},
+		{VersionString: "1.2.3-alpha.01", ExpectedPart: PRE_RELEASE_PART, ExpectedReason: ErrLeadingZero},
+		{VersionString: "1.2.3-alpha_1", ExpectedPart: PRE_RELEASE_PART, ExpectedReason: ErrInvalidCharacter},
+		{VersionString: "1.2.3+build.", ExpectedPart: META_DATA_PART, ExpectedReason: ErrEmptyIdentifier},
+		{VersionString: "1.0.0+21AF26D3+++117B344092BD", ExpectedPart: META_DATA_PART, ExpectedReason: ErrInvalidCharacter},
+		{VersionString: "1.0.0+exp.sha.5114f85()", ExpectedPart: META_DATA_PART, ExpectedReason: ErrInvalidCharacter},
+		{VersionString: "1.2.3-alpha-1!", ExpectedPart: PRE_RELEASE_PART, ExpectedReason: ErrInvalidCharacter},
+		// The spec disallows numeric identifiers to have leading zeros, i.e. the 00065 identifier
+		{VersionString: "4.18.0-next.1599210529.00065", ExpectedPart: PRE_RELEASE_PART, ExpectedReason: ErrLeadingZero},
+	}
+
+	for _, test := range tests {
+		fmt.Printf("\nTesting strict parsing of %s\n", test.VersionString)
+
+		version, _, err := ParseSemverWithOptions(test.VersionString, ParseOptions{Strict: true})
+
+		if test.ExpectedReason == nil {
+			test.ExpectedResult.Raw = test.VersionString
+			if err != nil || version != test.ExpectedResult {
+				fmt.Printf("✗ Failed. Expected: %+v, but got: %+v (%v)\n", test.ExpectedResult, version, err)
+				t.Errorf("✗ Failed. Expected: %+v, but got: %+v (%v)\n", test.ExpectedResult, version, err)
+			} else {
+				fmt.Println("✓ Success")
+			}
+			continue
+		}
+
+		var invalidVersion *InvalidVersionError
+		if !errors.As(err, &invalidVersion) || invalidVersion.Part != test.ExpectedPart || !errors.Is(err, test.ExpectedReason) {
+			fmt.Printf("✗ Failed. Expected %s error '%s', but got: %v\n", test.ExpectedPart, test.ExpectedReason, err)
+			t.Errorf("✗ Failed. Expected %s error '%s', but got: %v\n", test.ExpectedPart, test.ExpectedReason, err)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestCoerce(t *testing.T) {
+
+	fmt.Printf("\n%s Testing coercion of strings into versions %s\n", "----------------", "----------------")
+
+	tests := []CoerceTest{
+		{String: "1.2.3", ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3}, ExpectedSpan: Span{Start: 0, End: 5}, ExpectedOk: true},
+		{String: "v2", ExpectedResult: Semver{Major: 2, Minor: 0, Patch: 0}, ExpectedSpan: Span{Start: 1, End: 2}, ExpectedOk: true},
+		{String: "release-1.4.7-final", ExpectedResult: Semver{Major: 1, Minor: 4, Patch: 7}, ExpectedSpan: Span{Start: 8, End: 13}, ExpectedOk: true},
+		{String: "release-1.4.7-final", Options: CoerceOptions{IncludePreRelease: true}, ExpectedResult: Semver{Major: 1, Minor: 4, Patch: 7, PreReleaseTag: "final"}, ExpectedSpan: Span{Start: 8, End: 19}, ExpectedOk: true},
+		{String: "OpenSSL 3.0.2 15 Mar 2022", ExpectedResult: Semver{Major: 3, Minor: 0, Patch: 2}, ExpectedSpan: Span{Start: 8, End: 13}, ExpectedOk: true},
+		{String: "OpenSSL 3.0.2 15 Mar 2022", Options: CoerceOptions{RTL: true}, ExpectedResult: Semver{Major: 2022, Minor: 0, Patch: 0}, ExpectedSpan: Span{Start: 21, End: 25}, ExpectedOk: true},
+		{String: "42.6.7.9.3-alpha", ExpectedResult: Semver{Major: 42, Minor: 6, Patch: 7}, ExpectedSpan: Span{Start: 0, End: 6}, ExpectedOk: true},
+		{String: "42.6.7.9.3-alpha", Options: CoerceOptions{RTL: true}, ExpectedResult: Semver{Major: 7, Minor: 9, Patch: 3}, ExpectedSpan: Span{Start: 5, End: 10}, ExpectedOk: true},
+		{String: "42.6.7.9.3-alpha", Options: CoerceOptions{RTL: true, IncludePreRelease: true}, ExpectedResult: Semver{Major: 7, Minor: 9, Patch: 3, PreReleaseTag: "alpha"}, ExpectedSpan: Span{Start: 5, End: 16}, ExpectedOk: true},
+		{String: "1.2.3.4", Options: CoerceOptions{RTL: true}, ExpectedResult: Semver{Major: 2, Minor: 3, Patch: 4}, ExpectedSpan: Span{Start: 2, End: 7}, ExpectedOk: true},
+		{String: "1.2.3-beta.1+build.5 tail", Options: CoerceOptions{IncludePreRelease: true}, ExpectedResult: Semver{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: "beta.1", MetaData: "build.5"}, ExpectedSpan: Span{Start: 0, End: 20}, ExpectedOk: true},
+		// A version part has at most 16 digits
+		{String: "12345678901234567.1", ExpectedResult: Semver{Major: 1, Minor: 0, Patch: 0}, ExpectedSpan: Span{Start: 18, End: 19}, ExpectedOk: true},
+		{String: "version one", ExpectedResult: Semver{}},
+		{String: "", ExpectedResult: Semver{}},
+	}
+
+	for _, test := range tests {
+		fmt.Printf("\nTesting coercion of '%s' with %+v\n", test.String, test.Options)
+
+		version, span, ok := CoerceWithSpan(test.String, test.Options)
+		if test.ExpectedOk {
+			// The raw literal of a coerced version is the part of the string it was extracted from
+			test.ExpectedResult.Raw = test.String[test.ExpectedSpan.Start:test.ExpectedSpan.End]
+		}
+
+		if ok != test.ExpectedOk || version != test.ExpectedResult || span != test.ExpectedSpan {
+			fmt.Printf("✗ Failed. Expected: %+v %v, but got: %+v %v\n", test.ExpectedResult, test.ExpectedSpan, version, span)
+			t.Errorf("✗ Failed. Expected: %+v %v, but got: %+v %v\n", test.ExpectedResult, test.ExpectedSpan, version, span)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestFormat(t *testing.T) {
+
+	fmt.Printf("\n%s Testing formatting of versions %s\n", "----------------", "----------------")
+
+	tests := []FormatTest{
+		{VersionString: "1.2.3", Ecosystem: "nodejs", ExpectedCanonical: "1.2.3", ExpectedNative: "1.2.3"},
+		{VersionString: "v1.2.3", Ecosystem: "nodejs", ExpectedCanonical: "1.2.3", ExpectedNative: "1.2.3"},
+		// The prerelease precedes the build metadata
+		{VersionString: "1.0.0-beta+build", Ecosystem: "nodejs", ExpectedCanonical: "1.0.0-beta+build", ExpectedNative: "1.0.0-beta+build"},
+		// Everything after the first '+' is build metadata
+		{VersionString: "1.0.0+build-1", Ecosystem: "nodejs", ExpectedCanonical: "1.0.0+build-1", ExpectedNative: "1.0.0+build-1"},
+		{VersionString: "dev-master", Ecosystem: "composer", ExpectedCanonical: "9999999.9999999.9999999", ExpectedNative: "dev-master"},
+		{VersionString: "1.0.x-dev", Ecosystem: "composer", ExpectedCanonical: "1.0.9999999", ExpectedNative: "1.0.x-dev"},
+		{VersionString: "2.1.0-dev", Ecosystem: "composer", ExpectedCanonical: "2.1.0", ExpectedNative: "2.1.0-dev"},
+		{VersionString: "1.0.0@beta", Ecosystem: "composer", ExpectedCanonical: "1.0.0", ExpectedNative: "1.0.0@beta"},
+		// Composer versions are written in their normalized form, with the revision if it is given
+		{VersionString: "1.2.3.4", Ecosystem: "composer", ExpectedCanonical: "1.2.3", ExpectedNative: "1.2.3.4"},
+		{VersionString: "1.0.0-pl1", Ecosystem: "composer", ExpectedCanonical: "1.0.0-patch1", ExpectedNative: "1.0.0-patch1"},
+		{VersionString: "dev-main as 1.0.x-dev", Ecosystem: "composer", ExpectedCanonical: "1.0.9999999", ExpectedNative: "dev-main as 1.0.x-dev"},
+	}
+
+	for _, test := range tests {
+		fmt.Printf("\nTesting formatting of %s\n", test.VersionString)
+
+		version, err := ParseSemverWithEcosystem(test.VersionString, test.Ecosystem)
+		if err != nil {
+			t.Fatalf("✗ failed parsing of version: '%s'. %s\n", test.VersionString, err)
+		}
+
+		canonical := version.Format(CANONICAL_FORMAT)
+		original := version.Format(ORIGINAL_FORMAT)
+		native := version.Format(NATIVE_FORMAT)
+		if canonical != test.ExpectedCanonical || original != test.VersionString || native != test.ExpectedNative {
+			fmt.Printf("✗ Failed. Expected: %s %s %s, but got: %s %s %s\n", test.ExpectedCanonical, test.VersionString, test.ExpectedNative, canonical, original, native)
+			t.Errorf("✗ Failed. Expected: %s %s %s, but got: %s %s %s\n", test.ExpectedCanonical, test.VersionString, test.ExpectedNative, canonical, original, native)
+			continue
+		}
+
+		// The native form parses to the same version
+		reparsed, err := ParseSemverWithEcosystem(native, test.Ecosystem)
+		reparsed.Raw = version.Raw
+		if err != nil || reparsed != version {
+			fmt.Printf("✗ Failed round trip. Expected: %+v, but got: %+v (%v)\n", version, reparsed, err)
+			t.Errorf("✗ Failed round trip. Expected: %+v, but got: %+v (%v)\n", version, reparsed, err)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	// Versions that were not parsed have no original literal
+	if formatted := (Semver{Major: 1, PreReleaseTag: "rc.1"}).Format(ORIGINAL_FORMAT); formatted != "1.0.0-rc.1" {
+		t.Errorf("✗ Failed. Expected: 1.0.0-rc.1, but got: %s\n", formatted)
+	}
+
+	fmt.Printf("\n")
+}
+
 func testPreReleaseComparisons(t *testing.T, comparisons []PreReleaseComparisonToTest[bool], comperatorOperator string) {
 
 	for _, comparisonToTest := range comparisons {
@@ -1006,3 +1295,238 @@ func testVersionParsing(t *testing.T, tests []VersionParsingTest) {
 
 	}
 }
+
+type ComposerNormalizationTest struct {
+	VersionString      string
+	ExpectedNormalized string
+	ExpectedStability  string
+	ExpectedError      error
+}
+
+func TestComposerNormalization(t *testing.T) {
+
+	fmt.Printf("\n%s Testing Composer version normalization %s\n", "----------------", "----------------")
+
+	tests := []ComposerNormalizationTest{
+		{VersionString: "1.2", ExpectedNormalized: "1.2.0.0", ExpectedStability: "stable"},
+		{VersionString: "1.2.3.4", ExpectedNormalized: "1.2.3.4", ExpectedStability: "stable"},
+		{VersionString: "1.0.0-beta1", ExpectedNormalized: "1.0.0.0-beta1", ExpectedStability: "beta"},
+		{VersionString: "1.0.0-beta.1", ExpectedNormalized: "1.0.0.0-beta1", ExpectedStability: "beta"},
+		{VersionString: "1.0.0b2", ExpectedNormalized: "1.0.0.0-beta2", ExpectedStability: "beta"},
+		{VersionString: "1.0.0-RC2", ExpectedNormalized: "1.0.0.0-RC2", ExpectedStability: "RC"},
+		{VersionString: "1.0.0-patch3", ExpectedNormalized: "1.0.0.0-patch3", ExpectedStability: "stable"},
+		{VersionString: "1.0.0-pl1", ExpectedNormalized: "1.0.0.0-patch1", ExpectedStability: "stable"},
+		{VersionString: "v2.0.0-p1", ExpectedNormalized: "2.0.0.0-patch1", ExpectedStability: "stable"},
+		{VersionString: "1.0.0-stable", ExpectedNormalized: "1.0.0.0", ExpectedStability: "stable"},
+		{VersionString: "1.0.0-RC2-dev", ExpectedNormalized: "1.0.0.0-RC2-dev", ExpectedStability: "dev"},
+		{VersionString: "1.0.x-dev", ExpectedNormalized: "1.0.9999999.9999999-dev", ExpectedStability: "dev"},
+		{VersionString: "dev-main", ExpectedNormalized: "dev-main", ExpectedStability: "dev"},
+		{VersionString: "1.0.0+build", ExpectedNormalized: "1.0.0.0", ExpectedStability: "stable"},
+		{VersionString: "2023-10-15", ExpectedNormalized: "2023.10.15.0", ExpectedStability: "stable"},
+
+		{VersionString: "1.0.0-foo", ExpectedError: ErrInvalidVersionParts},
+		{VersionString: "1.2.3.4.5", ExpectedError: ErrInvalidVersionParts},
+		{VersionString: "1.2.3.18446744073709551616", ExpectedError: ErrVersionPartOverflow},
+	}
+
+	for _, test := range tests {
+		fmt.Printf("\nTesting normalization of %s\n", test.VersionString)
+
+		normalized, err := NormalizeComposer(test.VersionString)
+		if test.ExpectedError != nil {
+			if !errors.Is(err, test.ExpectedError) {
+				fmt.Printf("✗ Failed. Expected error '%s', but got: %v\n", test.ExpectedError, err)
+				t.Errorf("✗ Failed. Expected error '%s', but got: %v\n", test.ExpectedError, err)
+			} else {
+				fmt.Println("✓ Success")
+			}
+			continue
+		}
+
+		version, _ := ParseSemverWithEcosystem(test.VersionString, "composer")
+		if err != nil || normalized != test.ExpectedNormalized || version.ComposerStability() != test.ExpectedStability {
+			fmt.Printf("✗ Failed. Expected: %s (%s), but got: %s (%s) %v\n", test.ExpectedNormalized, test.ExpectedStability, normalized, version.ComposerStability(), err)
+			t.Errorf("✗ Failed. Expected: %s (%s), but got: %s (%s) %v\n", test.ExpectedNormalized, test.ExpectedStability, normalized, version.ComposerStability(), err)
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestComposerPrecedence(t *testing.T) {
+
+	fmt.Printf("\n%s Testing Composer version precedence %s\n", "----------------", "----------------")
+
+	// Each version precedes the next one
+	ordered := []string{"1.0.0-dev", "1.0.0-alpha1", "1.0.0-beta1-dev", "1.0.0-beta1", "1.0.0-beta2", "1.0.0-beta10", "1.0.0-RC1", "1.0.0", "1.0.0-patch1", "1.0.0.1", "1.0.1-dev"}
+
+	for idx := 0; idx+1 < len(ordered); idx++ {
+		fmt.Printf("\nTesting %s < %s\n", ordered[idx], ordered[idx+1])
+
+		v1, err1 := ParseSemverWithEcosystem(ordered[idx], "composer")
+		v2, err2 := ParseSemverWithEcosystem(ordered[idx+1], "composer")
+		if err1 != nil || err2 != nil {
+			t.Fatalf("✗ failed parsing of versions: %v %v\n", err1, err2)
+		}
+
+		if !v1.LT(v2, false) || !v1.LE(v2, false) || v1.GT(v2, false) || v1.GE(v2, false) || v1.EQ(v2, false) || v1.Compare(v2, false) != -1 || v2.Compare(v1, false) != 1 {
+			fmt.Printf("✗ Failed. Expected %s to precede %s\n", ordered[idx], ordered[idx+1])
+			t.Errorf("✗ Failed. Expected %s to precede %s\n", ordered[idx], ordered[idx+1])
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestComposerBranchAlias(t *testing.T) {
+
+	fmt.Printf("\n%s Testing Composer branch aliases %s\n", "----------------", "----------------")
+
+	branchAliases := map[string]string{"dev-main": "2.1.x-dev", "dev-broken": "dev-main"}
+
+	aliasesToTest := []struct {
+		VersionString     string
+		ExpectedNative    string
+		ExpectedUnaliased bool
+		ExpectedErr       error
+	}{
+		{VersionString: "dev-main", ExpectedNative: "dev-main as 2.1.x-dev"},
+		{VersionString: "dev-main as 1.0.x-dev", ExpectedNative: "dev-main as 1.0.x-dev"},
+		{VersionString: "dev-feature", ExpectedNative: "dev-feature", ExpectedUnaliased: true},
+		{VersionString: "1.0.0", ExpectedNative: "1.0.0"},
+		{VersionString: "dev-broken", ExpectedErr: ErrInvalidBranchAlias},
+		{VersionString: "1.0.0 as 1.0.x-dev", ExpectedErr: ErrNotABranch},
+	}
+
+	for _, aliasToTest := range aliasesToTest {
+		fmt.Printf("\nTesting branch alias of '%s'\n", aliasToTest.VersionString)
+
+		semver, err := ParseSemverWithEcosystem(aliasToTest.VersionString, "composer")
+		if err == nil {
+			semver, err = semver.WithBranchAlias(branchAliases)
+		}
+		if aliasToTest.ExpectedErr != nil {
+			if !errors.Is(err, aliasToTest.ExpectedErr) {
+				fmt.Printf("✗ Failed. Expected error '%s', but got: %v\n", aliasToTest.ExpectedErr, err)
+				t.Errorf("✗ Failed. Expected error '%s', but got: %v\n", aliasToTest.ExpectedErr, err)
+			} else {
+				fmt.Println("✓ Success")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("✗ failed aliasing of version: '%s'. %s\n", aliasToTest.VersionString, err)
+		}
+
+		native := semver.Format(NATIVE_FORMAT)
+		if native != aliasToTest.ExpectedNative || semver.IsUnaliasedBranch() != aliasToTest.ExpectedUnaliased {
+			fmt.Printf("✗ Failed. Expected: '%s' (%t), but got: '%s' (%t)\n", aliasToTest.ExpectedNative, aliasToTest.ExpectedUnaliased, native, semver.IsUnaliasedBranch())
+			t.Errorf("✗ Failed. Expected: '%s' (%t), but got: '%s' (%t)\n", aliasToTest.ExpectedNative, aliasToTest.ExpectedUnaliased, native, semver.IsUnaliasedBranch())
+		} else {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	// An aliased branch compares as its alias
+	aliased, _ := ParseSemverWithEcosystem("dev-main as 2.1.x-dev", "composer")
+	release, _ := ParseSemverWithEcosystem("2.1.0", "composer")
+	next, _ := ParseSemverWithEcosystem("2.2.0", "composer")
+	if !aliased.GT(release, false) || !aliased.LT(next, false) {
+		fmt.Printf("✗ Failed. Expected dev-main as 2.1.x-dev to lie between 2.1.0 and 2.2.0\n")
+		t.Errorf("✗ Failed. Expected dev-main as 2.1.x-dev to lie between 2.1.0 and 2.2.0\n")
+	}
+
+	// A wildcard part lies above the parts of the releases, as in Composer
+	wildcard, _ := ParseSemverWithEcosystem("1.0.x-dev", "composer")
+	patch, _ := ParseSemverWithEcosystem("1.0.1000", "composer")
+	if !wildcard.GT(patch, false) {
+		fmt.Printf("✗ Failed. Expected 1.0.x-dev > 1.0.1000\n")
+		t.Errorf("✗ Failed. Expected 1.0.x-dev > 1.0.1000\n")
+	}
+
+	fmt.Printf("\n")
+}
+
+func TestComparisonConsistency(t *testing.T) {
+
+	fmt.Printf("\n%s Testing consistency of the comparison methods %s\n", "----------------", "----------------")
+
+	versionsToTest := map[string][]string{
+		"nodejs":   {"1.0.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0+build", "1.0.1", "2.0.0-0", "20231015123456.1.2"},
+		"composer": {"1.0.0", "1.0.0-dev", "1.0.0-beta1", "1.0.0-RC1", "1.0.0-patch1", "1.0.0.1", "1.0.x-dev", "dev-main", "dev-feature", "dev-main as 1.0.x-dev", "dev-main as 2.1.x-dev", "1.0.0@beta"},
+	}
+
+	for ecosystem, versionStrings := range versionsToTest {
+		fmt.Printf("\nTesting comparisons of %s versions\n", ecosystem)
+
+		parsedVersions := []Semver{}
+		for _, versionString := range versionStrings {
+			parsedVersion, err := ParseSemverWithEcosystem(versionString, ecosystem)
+			if err != nil {
+				t.Fatalf("✗ failed parsing of version: '%s'. %s\n", versionString, err)
+			}
+			parsedVersions = append(parsedVersions, parsedVersion)
+		}
+
+		failed := false
+		for _, ignorePreRelease := range []bool{false, true} {
+			for i, v1 := range parsedVersions {
+				for j, v2 := range parsedVersions {
+					comparison := v1.Compare(v2, ignorePreRelease)
+
+					// All methods agree with Compare, which is antisymmetric
+					if v1.GE(v2, ignorePreRelease) != (comparison >= 0) || v1.GT(v2, ignorePreRelease) != (comparison > 0) ||
+						v1.LE(v2, ignorePreRelease) != (comparison <= 0) || v1.LT(v2, ignorePreRelease) != (comparison < 0) ||
+						v1.EQ(v2, ignorePreRelease) != (comparison == 0) || v1.NEQ(v2, ignorePreRelease) != (comparison != 0) ||
+						v2.Compare(v1, ignorePreRelease) != -comparison {
+						fmt.Printf("✗ Failed. Comparisons of '%s' and '%s' disagree\n", versionStrings[i], versionStrings[j])
+						t.Errorf("✗ Failed. Comparisons of '%s' and '%s' disagree\n", versionStrings[i], versionStrings[j])
+						failed = true
+					}
+
+					// The order is transitive
+					for k, v3 := range parsedVersions {
+						if comparison <= 0 && v2.Compare(v3, ignorePreRelease) <= 0 && v1.Compare(v3, ignorePreRelease) > 0 {
+							fmt.Printf("✗ Failed. '%s' <= '%s' <= '%s', but '%s' > '%s'\n", versionStrings[i], versionStrings[j], versionStrings[k], versionStrings[i], versionStrings[k])
+							t.Errorf("✗ Failed. '%s' <= '%s' <= '%s', but '%s' > '%s'\n", versionStrings[i], versionStrings[j], versionStrings[k], versionStrings[i], versionStrings[k])
+							failed = true
+						}
+					}
+				}
+			}
+		}
+
+		// Sorting gives the same order regardless of the order of the input
+		sorted := slices.Clone(parsedVersions)
+		slices.SortFunc(sorted, func(v1 Semver, v2 Semver) int { return v1.Compare(v2, false) })
+		reversed := slices.Clone(parsedVersions)
+		slices.Reverse(reversed)
+		slices.SortFunc(reversed, func(v1 Semver, v2 Semver) int { return v1.Compare(v2, false) })
+		for idx := range sorted {
+			if sorted[idx].Compare(reversed[idx], false) != 0 {
+				fmt.Printf("✗ Failed. Sorting disagrees at index %d: '%s' and '%s'\n", idx, sorted[idx].Raw, reversed[idx].Raw)
+				t.Errorf("✗ Failed. Sorting disagrees at index %d: '%s' and '%s'\n", idx, sorted[idx].Raw, reversed[idx].Raw)
+				failed = true
+			}
+		}
+
+		if !failed {
+			fmt.Println("✓ Success")
+		}
+	}
+
+	// Dev branches without alias follow all numbered versions, and are ordered by their names
+	feature, _ := ParseSemverWithEcosystem("dev-feature", "composer")
+	main, _ := ParseSemverWithEcosystem("dev-main", "composer")
+	release, _ := ParseSemverWithEcosystem("9999.0.0", "composer")
+	if !feature.GT(release, false) || !feature.LT(main, false) || !main.GE(feature, false) || main.LE(feature, false) {
+		fmt.Printf("✗ Failed. Expected 9999.0.0 < dev-feature < dev-main\n")
+		t.Errorf("✗ Failed. Expected 9999.0.0 < dev-feature < dev-main\n")
+	}
+
+	fmt.Printf("\n")
+}